@@ -9,6 +9,10 @@ func Module() fx.Option {
 	return fx.Options(
 		fx.Provide(
 			handlers.NewRouteHandler,
+			handlers.NewItineraryHandler,
+			handlers.NewAirportHandler,
+			handlers.NewAirlineHandler,
+			handlers.NewAutocompleteHandler,
 			handlers.NewHealthHandler,
 		),
 		fx.Invoke(NewServer),
@@ -13,6 +13,24 @@ import (
 
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// List airlines
+	// (GET /api/v1/airlines)
+	ListAirlines(c *gin.Context, params ListAirlinesParams)
+	// Get an airline
+	// (GET /api/v1/airlines/{code})
+	GetAirline(c *gin.Context, code string)
+	// Search airports
+	// (GET /api/v1/airports)
+	SearchAirports(c *gin.Context, params SearchAirportsParams)
+	// Get an airport
+	// (GET /api/v1/airports/{code})
+	GetAirport(c *gin.Context, code string)
+	// Autocomplete airports and cities
+	// (GET /api/v1/autocomplete)
+	Autocomplete(c *gin.Context, params AutocompleteParams)
+	// Search itineraries
+	// (GET /api/v1/itineraries)
+	GetItineraries(c *gin.Context, params GetItinerariesParams)
 	// Get flight routes
 	// (GET /api/v1/routes)
 	GetRoutes(c *gin.Context, params GetRoutesParams)
@@ -30,6 +48,250 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(c *gin.Context)
 
+// ListAirlines operation middleware
+func (siw *ServerInterfaceWrapper) ListAirlines(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params ListAirlinesParams
+
+	// ------------- Optional query parameter "active" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "active", c.Request.URL.Query(), &params.Active)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter active: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "alliance" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "alliance", c.Request.URL.Query(), &params.Alliance)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter alliance: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.ListAirlines(c, params)
+}
+
+// GetAirline operation middleware
+func (siw *ServerInterfaceWrapper) GetAirline(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAirline(c, code)
+}
+
+// SearchAirports operation middleware
+func (siw *ServerInterfaceWrapper) SearchAirports(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params SearchAirportsParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := c.Query("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument q is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.SearchAirports(c, params)
+}
+
+// GetAirport operation middleware
+func (siw *ServerInterfaceWrapper) GetAirport(c *gin.Context) {
+
+	var err error
+
+	// ------------- Path parameter "code" -------------
+	var code string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "code", c.Param("code"), &code, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter code: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetAirport(c, code)
+}
+
+// Autocomplete operation middleware
+func (siw *ServerInterfaceWrapper) Autocomplete(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params AutocompleteParams
+
+	// ------------- Required query parameter "q" -------------
+
+	if paramValue := c.Query("q"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument q is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "q", c.Request.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter q: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.Autocomplete(c, params)
+}
+
+// GetItineraries operation middleware
+func (siw *ServerInterfaceWrapper) GetItineraries(c *gin.Context) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetItinerariesParams
+
+	// ------------- Required query parameter "sourceAirport" -------------
+
+	if paramValue := c.Query("sourceAirport"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument sourceAirport is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "sourceAirport", c.Request.URL.Query(), &params.SourceAirport)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sourceAirport: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Required query parameter "destinationAirport" -------------
+
+	if paramValue := c.Query("destinationAirport"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandler(c, fmt.Errorf("Query argument destinationAirport is required, but not found"), http.StatusBadRequest)
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "destinationAirport", c.Request.URL.Query(), &params.DestinationAirport)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter destinationAirport: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "maxLegs" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxLegs", c.Request.URL.Query(), &params.MaxLegs)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxLegs: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "carrier" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "carrier", c.Request.URL.Query(), &params.Carrier)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter carrier: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter limit: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "expand", c.Request.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter expand: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		middleware(c)
+		if c.IsAborted() {
+			return
+		}
+	}
+
+	siw.Handler.GetItineraries(c, params)
+}
+
 // GetRoutes operation middleware
 func (siw *ServerInterfaceWrapper) GetRoutes(c *gin.Context) {
 
@@ -40,15 +302,23 @@ func (siw *ServerInterfaceWrapper) GetRoutes(c *gin.Context) {
 
 	// ------------- Optional query parameter "airline" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "airline", c.Request.URL.Query(), &params.Airline)
+	err = runtime.BindQueryParameter("form", false, false, "airline", c.Request.URL.Query(), &params.Airline)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter airline: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	// ------------- Optional query parameter "excludeAirline" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "excludeAirline", c.Request.URL.Query(), &params.ExcludeAirline)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter excludeAirline: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// ------------- Optional query parameter "sourceAirport" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "sourceAirport", c.Request.URL.Query(), &params.SourceAirport)
+	err = runtime.BindQueryParameter("form", false, false, "sourceAirport", c.Request.URL.Query(), &params.SourceAirport)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sourceAirport: %w", err), http.StatusBadRequest)
 		return
@@ -56,12 +326,76 @@ func (siw *ServerInterfaceWrapper) GetRoutes(c *gin.Context) {
 
 	// ------------- Optional query parameter "destinationAirport" -------------
 
-	err = runtime.BindQueryParameter("form", true, false, "destinationAirport", c.Request.URL.Query(), &params.DestinationAirport)
+	err = runtime.BindQueryParameter("form", false, false, "destinationAirport", c.Request.URL.Query(), &params.DestinationAirport)
 	if err != nil {
 		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter destinationAirport: %w", err), http.StatusBadRequest)
 		return
 	}
 
+	// ------------- Optional query parameter "nearLat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "nearLat", c.Request.URL.Query(), &params.NearLat)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter nearLat: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "nearLon" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "nearLon", c.Request.URL.Query(), &params.NearLon)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter nearLon: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "radiusKm" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radiusKm", c.Request.URL.Query(), &params.RadiusKm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter radiusKm: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "destinationNearLat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "destinationNearLat", c.Request.URL.Query(), &params.DestinationNearLat)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter destinationNearLat: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "destinationNearLon" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "destinationNearLon", c.Request.URL.Query(), &params.DestinationNearLon)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter destinationNearLon: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "destinationRadiusKm" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "destinationRadiusKm", c.Request.URL.Query(), &params.DestinationRadiusKm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter destinationRadiusKm: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "codeShare" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "codeShare", c.Request.URL.Query(), &params.CodeShare)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter codeShare: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "equipment" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "equipment", c.Request.URL.Query(), &params.Equipment)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter equipment: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// ------------- Optional query parameter "maxStops" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "maxStops", c.Request.URL.Query(), &params.MaxStops)
@@ -70,6 +404,30 @@ func (siw *ServerInterfaceWrapper) GetRoutes(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "minDistanceKm" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "minDistanceKm", c.Request.URL.Query(), &params.MinDistanceKm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter minDistanceKm: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "maxDistanceKm" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxDistanceKm", c.Request.URL.Query(), &params.MaxDistanceKm)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter maxDistanceKm: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", c.Request.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter sort: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	// ------------- Optional query parameter "limit" -------------
 
 	err = runtime.BindQueryParameter("form", true, false, "limit", c.Request.URL.Query(), &params.Limit)
@@ -86,6 +444,30 @@ func (siw *ServerInterfaceWrapper) GetRoutes(c *gin.Context) {
 		return
 	}
 
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", c.Request.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter cursor: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "expand" -------------
+
+	err = runtime.BindQueryParameter("form", false, false, "expand", c.Request.URL.Query(), &params.Expand)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter expand: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	// ------------- Optional query parameter "strict" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "strict", c.Request.URL.Query(), &params.Strict)
+	if err != nil {
+		siw.ErrorHandler(c, fmt.Errorf("Invalid format for parameter strict: %w", err), http.StatusBadRequest)
+		return
+	}
+
 	for _, middleware := range siw.HandlerMiddlewares {
 		middleware(c)
 		if c.IsAborted() {
@@ -136,6 +518,12 @@ func RegisterHandlersWithOptions(router gin.IRouter, si ServerInterface, options
 		ErrorHandler:       errorHandler,
 	}
 
+	router.GET(options.BaseURL+"/api/v1/airlines", wrapper.ListAirlines)
+	router.GET(options.BaseURL+"/api/v1/airlines/:code", wrapper.GetAirline)
+	router.GET(options.BaseURL+"/api/v1/airports", wrapper.SearchAirports)
+	router.GET(options.BaseURL+"/api/v1/airports/:code", wrapper.GetAirport)
+	router.GET(options.BaseURL+"/api/v1/autocomplete", wrapper.Autocomplete)
+	router.GET(options.BaseURL+"/api/v1/itineraries", wrapper.GetItineraries)
 	router.GET(options.BaseURL+"/api/v1/routes", wrapper.GetRoutes)
 	router.GET(options.BaseURL+"/health", wrapper.HealthCheck)
 }
@@ -3,26 +3,139 @@
 // Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.4.1 DO NOT EDIT.
 package gen
 
-import (
-	"time"
+// Defines values for AirlineAlliance.
+const (
+	AirlineAllianceOneworld     AirlineAlliance = "oneworld"
+	AirlineAllianceSkyteam      AirlineAlliance = "skyteam"
+	AirlineAllianceStarAlliance AirlineAlliance = "star_alliance"
 )
 
 // Defines values for FlightRouteCodeShare.
 const (
-	N FlightRouteCodeShare = "N"
-	Y FlightRouteCodeShare = "Y"
+	FlightRouteCodeShareN FlightRouteCodeShare = "N"
+	FlightRouteCodeShareY FlightRouteCodeShare = "Y"
 )
 
-// ErrorResponse defines model for ErrorResponse.
-type ErrorResponse struct {
-	// Code HTTP status code
-	Code int `json:"code"`
+// Defines values for GetItinerariesParamsCarrier.
+const (
+	GetItinerariesParamsCarrierAirline  GetItinerariesParamsCarrier = "airline"
+	GetItinerariesParamsCarrierAlliance GetItinerariesParamsCarrier = "alliance"
+	GetItinerariesParamsCarrierAny      GetItinerariesParamsCarrier = "any"
+)
+
+// Defines values for GetItinerariesParamsExpand.
+const (
+	GetItinerariesParamsExpandAirlines GetItinerariesParamsExpand = "airlines"
+	GetItinerariesParamsExpandAirports GetItinerariesParamsExpand = "airports"
+)
+
+// Defines values for GetRoutesParamsCodeShare.
+const (
+	GetRoutesParamsCodeShareN GetRoutesParamsCodeShare = "N"
+	GetRoutesParamsCodeShareY GetRoutesParamsCodeShare = "Y"
+)
+
+// Defines values for GetRoutesParamsExpand.
+const (
+	GetRoutesParamsExpandAirlines GetRoutesParamsExpand = "airlines"
+	GetRoutesParamsExpandAirports GetRoutesParamsExpand = "airports"
+)
+
+// Defines values for ListAirlinesParamsAlliance.
+const (
+	ListAirlinesParamsAllianceOneworld     ListAirlinesParamsAlliance = "oneworld"
+	ListAirlinesParamsAllianceSkyteam      ListAirlinesParamsAlliance = "skyteam"
+	ListAirlinesParamsAllianceStarAlliance ListAirlinesParamsAlliance = "star_alliance"
+)
+
+// Defines values for ProblemCode.
+const (
+	Gone                ProblemCode = "gone"
+	Internal            ProblemCode = "internal"
+	NotFound            ProblemCode = "not_found"
+	RateLimited         ProblemCode = "rate_limited"
+	UpstreamUnavailable ProblemCode = "upstream_unavailable"
+	Validation          ProblemCode = "validation"
+)
+
+// Defines values for ProviderStatus.
+const (
+	Failed  ProviderStatus = "failed"
+	Ok      ProviderStatus = "ok"
+	Skipped ProviderStatus = "skipped"
+	Stale   ProviderStatus = "stale"
+)
+
+// Defines values for SuggestionType.
+const (
+	SuggestionTypeAirport SuggestionType = "airport"
+	SuggestionTypeMetro   SuggestionType = "metro"
+)
+
+// Airline Airline of the reference catalog
+type Airline struct {
+	// Active False for airlines that ceased operations
+	Active bool `json:"active"`
+
+	// Alliance Alliance the airline is a member of
+	Alliance *AirlineAlliance `json:"alliance,omitempty"`
+
+	// Callsign Radio callsign
+	Callsign *string `json:"callsign,omitempty"`
+	Country  string  `json:"country"`
+
+	// Iata IATA 2-letter code
+	Iata string `json:"iata"`
+
+	// Icao ICAO 3-letter code
+	Icao *string `json:"icao,omitempty"`
+	Name string  `json:"name"`
+}
+
+// AirlineAlliance Alliance the airline is a member of
+type AirlineAlliance string
+
+// AirlinesResponse defines model for AirlinesResponse.
+type AirlinesResponse struct {
+	Data []Airline `json:"data"`
+}
+
+// Airport Airport of the reference catalog
+type Airport struct {
+	// City City served by the airport
+	City    string `json:"city"`
+	Country string `json:"country"`
+
+	// Iata IATA 3-letter code
+	Iata string `json:"iata"`
+
+	// Icao ICAO 4-letter code
+	Icao *string `json:"icao,omitempty"`
+
+	// Latitude Latitude in decimal degrees
+	Latitude float64 `json:"latitude"`
+
+	// Longitude Longitude in decimal degrees
+	Longitude float64 `json:"longitude"`
+	Name      string  `json:"name"`
+
+	// Timezone Time zone in the tz database
+	Timezone *string `json:"timezone,omitempty"`
+}
+
+// AirportsResponse defines model for AirportsResponse.
+type AirportsResponse struct {
+	// Data Matching airports, exact code matches first
+	Data []Airport `json:"data"`
+}
 
-	// Error Error message
-	Error string `json:"error"`
+// FieldError defines model for FieldError.
+type FieldError struct {
+	// Field Name of the invalid field or parameter
+	Field string `json:"field"`
 
-	// Timestamp Error timestamp
-	Timestamp time.Time `json:"timestamp"`
+	// Message Why the value was rejected
+	Message string `json:"message"`
 }
 
 // FlightRoute defines model for FlightRoute.
@@ -30,21 +143,45 @@ type FlightRoute struct {
 	// Airline Airline code (IATA 2-letter code)
 	Airline string `json:"airline"`
 
+	// AirlineDetails Airline of the reference catalog
+	AirlineDetails *Airline `json:"airlineDetails,omitempty"`
+
 	// CodeShare Code share information
 	CodeShare FlightRouteCodeShare `json:"codeShare"`
 
 	// DestinationAirport Destination airport code (IATA 3-letter code)
 	DestinationAirport string `json:"destinationAirport"`
 
+	// DestinationAirportDetails Airport of the reference catalog
+	DestinationAirportDetails *Airport `json:"destinationAirportDetails,omitempty"`
+
+	// DestinationMetro Metropolitan area code of the destinationAirport filter the route matched through
+	DestinationMetro *string `json:"destinationMetro,omitempty"`
+
+	// DistanceKm Great-circle distance between the airports in kilometres, if both are in the airport catalog
+	DistanceKm *int `json:"distanceKm,omitempty"`
+
 	// Equipment Equipment type (optional)
 	Equipment *string `json:"equipment"`
 
+	// EstimatedDurationMinutes Estimated gate to gate time in minutes, if the distance is known
+	EstimatedDurationMinutes *int `json:"estimatedDurationMinutes,omitempty"`
+
 	// Provider Data provider source
 	Provider *string `json:"provider,omitempty"`
 
+	// Providers Every provider offering the route, in the order providers are queried
+	Providers []string `json:"providers"`
+
 	// SourceAirport Source airport code (IATA 3-letter code)
 	SourceAirport string `json:"sourceAirport"`
 
+	// SourceAirportDetails Airport of the reference catalog
+	SourceAirportDetails *Airport `json:"sourceAirportDetails,omitempty"`
+
+	// SourceMetro Metropolitan area code of the sourceAirport filter the route matched through
+	SourceMetro *string `json:"sourceMetro,omitempty"`
+
 	// Stops Number of stops
 	Stops int `json:"stops"`
 }
@@ -52,29 +189,270 @@ type FlightRoute struct {
 // FlightRouteCodeShare Code share information
 type FlightRouteCodeShare string
 
+// ItinerariesResponse defines model for ItinerariesResponse.
+type ItinerariesResponse struct {
+	// Data Itineraries, fewest legs first
+	Data []Itinerary `json:"data"`
+	Meta RoutesMeta  `json:"meta"`
+}
+
+// Itinerary defines model for Itinerary.
+type Itinerary struct {
+	// Legs Routes to take in order, each departing from the airport the previous one arrives at
+	Legs []FlightRoute `json:"legs"`
+
+	// MinConnectionMinutes Sum of the minimum times needed to change planes at every connection. Connections between different airlines need longer than those within one airline
+	MinConnectionMinutes int `json:"minConnectionMinutes"`
+}
+
+// Pagination Position of the page within all matching routes
+type Pagination struct {
+	// HasMore True when routes follow the page
+	HasMore bool `json:"hasMore"`
+
+	// Limit Maximum number of routes in the page
+	Limit int `json:"limit"`
+
+	// Next URL of the next page, present when hasMore is true. Uses nextCursor when the request had a cursor
+	Next *string `json:"next,omitempty"`
+
+	// NextCursor Cursor selecting the next page from the same snapshot, present when hasMore is true
+	NextCursor *string `json:"nextCursor,omitempty"`
+
+	// Offset Number of matching routes before the page
+	Offset int `json:"offset"`
+
+	// Prev URL of the previous page, present when offset is positive. Uses prevCursor when the request had a cursor
+	Prev *string `json:"prev,omitempty"`
+
+	// PrevCursor Cursor selecting the previous page from the same snapshot, present when offset is positive
+	PrevCursor *string `json:"prevCursor,omitempty"`
+
+	// Total Number of routes matching the filters across all pages
+	Total int `json:"total"`
+}
+
+// Problem Error details as defined by RFC 7807
+type Problem struct {
+	// Code Machine-readable error code
+	Code ProblemCode `json:"code"`
+
+	// Detail Explanation specific to this occurrence of the problem
+	Detail string `json:"detail"`
+
+	// Errors Request fields that failed validation
+	Errors *[]FieldError `json:"errors,omitempty"`
+
+	// Instance ID of the request the problem occurred in, as returned in X-Request-ID
+	Instance string `json:"instance"`
+
+	// Status HTTP status code
+	Status int `json:"status"`
+
+	// Title Short summary of the problem type
+	Title string `json:"title"`
+
+	// Type URI identifying the problem type
+	Type string `json:"type"`
+}
+
+// ProblemCode Machine-readable error code
+type ProblemCode string
+
+// ProviderMeta defines model for ProviderMeta.
+type ProviderMeta struct {
+	// Error Reason the provider failed or is stale, `timeout` or `upstream error`
+	Error *string `json:"error,omitempty"`
+
+	// LatencyMs Time spent fetching routes from the provider, in milliseconds
+	LatencyMs int `json:"latencyMs"`
+
+	// Name Provider name
+	Name string `json:"name"`
+
+	// RouteCount Number of routes the provider contributed before filtering
+	RouteCount int `json:"routeCount"`
+
+	// Status Outcome of querying a provider
+	Status ProviderStatus `json:"status"`
+}
+
+// ProviderStatus Outcome of querying a provider
+type ProviderStatus string
+
+// RoutesMeta defines model for RoutesMeta.
+type RoutesMeta struct {
+	// Providers Outcome of querying each configured provider
+	Providers []ProviderMeta `json:"providers"`
+
+	// Stale True when at least one provider served cached routes past their TTL
+	Stale bool `json:"stale"`
+}
+
 // RoutesResponse defines model for RoutesResponse.
 type RoutesResponse struct {
 	// Data Array of flight routes
 	Data []FlightRoute `json:"data"`
+	Meta RoutesMeta    `json:"meta"`
+
+	// Pagination Position of the page within all matching routes
+	Pagination Pagination `json:"pagination"`
+}
+
+// Suggestion Airport or metropolitan area suggested for an autocomplete query
+type Suggestion struct {
+	// Airports Airports of a metropolitan area
+	Airports *[]string `json:"airports,omitempty"`
+	City     *string   `json:"city,omitempty"`
+
+	// Code IATA code, usable as sourceAirport or destinationAirport
+	Code    string  `json:"code"`
+	Country *string `json:"country,omitempty"`
+
+	// Name Airport name, or the city of a metropolitan area
+	Name string `json:"name"`
+
+	// RouteCount Number of routes from or to the airports in the aggregated data
+	RouteCount int `json:"routeCount"`
+
+	// Type Whether the code is the one of an airport or of a metropolitan area
+	Type SuggestionType `json:"type"`
+}
+
+// SuggestionType Whether the code is the one of an airport or of a metropolitan area
+type SuggestionType string
+
+// SuggestionsResponse defines model for SuggestionsResponse.
+type SuggestionsResponse struct {
+	// Data Suggestions, best first
+	Data []Suggestion `json:"data"`
+}
+
+// AutocompleteParams defines parameters for Autocomplete.
+type AutocompleteParams struct {
+	// Q Text typed so far
+	Q string `form:"q" json:"q"`
+
+	// Limit Maximum number of suggestions to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetItinerariesParams defines parameters for GetItineraries.
+type GetItinerariesParams struct {
+	// SourceAirport Airport to depart from
+	SourceAirport string `form:"sourceAirport" json:"sourceAirport"`
+
+	// DestinationAirport Airport to arrive at
+	DestinationAirport string `form:"destinationAirport" json:"destinationAirport"`
+
+	// MaxLegs Maximum number of routes an itinerary may combine
+	MaxLegs *int `form:"maxLegs,omitempty" json:"maxLegs,omitempty"`
+
+	// Carrier Restricts the airlines an itinerary may combine. "airline" requires every leg to be flown by the same airline, "alliance" by the same airline or members of one alliance.
+	Carrier *GetItinerariesParamsCarrier `form:"carrier,omitempty" json:"carrier,omitempty"`
+
+	// Limit Maximum number of itineraries to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Expand Related resources to embed, comma separated. "airports" adds sourceAirportDetails and destinationAirportDetails, "airlines" adds airlineDetails to every route whose airport or airline is in the catalog
+	Expand *[]GetItinerariesParamsExpand `form:"expand,omitempty" json:"expand,omitempty"`
 }
 
+// GetItinerariesParamsCarrier defines parameters for GetItineraries.
+type GetItinerariesParamsCarrier string
+
+// GetItinerariesParamsExpand defines parameters for GetItineraries.
+type GetItinerariesParamsExpand string
+
 // GetRoutesParams defines parameters for GetRoutes.
 type GetRoutesParams struct {
-	// Airline Filter by airline code
-	Airline *string `form:"airline,omitempty" json:"airline,omitempty"`
+	// Airline Filter by airline codes, comma separated. Matches routes of any of the airlines. Codes missing from the airline catalog are rejected
+	Airline *[]string `form:"airline,omitempty" json:"airline,omitempty"`
+
+	// ExcludeAirline Airline codes to leave out, comma separated. Codes missing from the airline catalog are rejected
+	ExcludeAirline *[]string `form:"excludeAirline,omitempty" json:"excludeAirline,omitempty"`
+
+	// SourceAirport Filter by source airport codes, comma separated. Matches routes from any of the airports. Metropolitan area codes such as LON match every airport of the area
+	SourceAirport *[]string `form:"sourceAirport,omitempty" json:"sourceAirport,omitempty"`
+
+	// DestinationAirport Filter by destination airport codes, comma separated. Matches routes to any of the airports. Metropolitan area codes such as NYC match every airport of the area
+	DestinationAirport *[]string `form:"destinationAirport,omitempty" json:"destinationAirport,omitempty"`
+
+	// NearLat Latitude of a location to depart near, in decimal degrees. Requires nearLon and radiusKm
+	NearLat *float64 `form:"nearLat,omitempty" json:"nearLat,omitempty"`
+
+	// NearLon Longitude of a location to depart near, in decimal degrees. Requires nearLat and radiusKm
+	NearLon *float64 `form:"nearLon,omitempty" json:"nearLon,omitempty"`
+
+	// RadiusKm Matches routes from any airport of the catalog within this many kilometres of nearLat, nearLon. Cannot be combined with sourceAirport
+	RadiusKm *int `form:"radiusKm,omitempty" json:"radiusKm,omitempty"`
+
+	// DestinationNearLat Latitude of a location to arrive near, in decimal degrees. Requires destinationNearLon and destinationRadiusKm
+	DestinationNearLat *float64 `form:"destinationNearLat,omitempty" json:"destinationNearLat,omitempty"`
+
+	// DestinationNearLon Longitude of a location to arrive near, in decimal degrees. Requires destinationNearLat and destinationRadiusKm
+	DestinationNearLon *float64 `form:"destinationNearLon,omitempty" json:"destinationNearLon,omitempty"`
+
+	// DestinationRadiusKm Matches routes to any airport of the catalog within this many kilometres of destinationNearLat, destinationNearLon. Cannot be combined with destinationAirport
+	DestinationRadiusKm *int `form:"destinationRadiusKm,omitempty" json:"destinationRadiusKm,omitempty"`
 
-	// SourceAirport Filter by source airport code
-	SourceAirport *string `form:"sourceAirport,omitempty" json:"sourceAirport,omitempty"`
+	// CodeShare Filter by code share flag
+	CodeShare *GetRoutesParamsCodeShare `form:"codeShare,omitempty" json:"codeShare,omitempty"`
 
-	// DestinationAirport Filter by destination airport code
-	DestinationAirport *string `form:"destinationAirport,omitempty" json:"destinationAirport,omitempty"`
+	// Equipment Filter by aircraft types, comma separated. Matches routes operated with any of the types
+	Equipment *[]string `form:"equipment,omitempty" json:"equipment,omitempty"`
 
 	// MaxStops Maximum number of stops
 	MaxStops *int `form:"maxStops,omitempty" json:"maxStops,omitempty"`
 
+	// MinDistanceKm Minimum great-circle distance in kilometres. Routes of unknown distance are left out
+	MinDistanceKm *int `form:"minDistanceKm,omitempty" json:"minDistanceKm,omitempty"`
+
+	// MaxDistanceKm Maximum great-circle distance in kilometres. Routes of unknown distance are left out
+	MaxDistanceKm *int `form:"maxDistanceKm,omitempty" json:"maxDistanceKm,omitempty"`
+
+	// Sort Comma separated FlightRoute fields to order routes by, each prefixed with "-" for descending order, e.g. "stops,-airline". Defaults to sourceAirport,destinationAirport,airline.
+	Sort *string `form:"sort,omitempty" json:"sort,omitempty"`
+
 	// Limit Maximum number of routes to return
 	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 
 	// Offset Offset for pagination
 	Offset *int `form:"offset,omitempty" json:"offset,omitempty"`
+
+	// Cursor Opaque cursor from pagination.nextCursor or pagination.prevCursor. Pages reached through cursors are served from the same snapshot of provider data, so walking every page sees a consistent dataset. Must be used with the filters of the request it came from and cannot be combined with offset.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+
+	// Expand Related resources to embed, comma separated. "airports" adds sourceAirportDetails and destinationAirportDetails, "airlines" adds airlineDetails to every route whose airport or airline is in the catalog
+	Expand *[]GetRoutesParamsExpand `form:"expand,omitempty" json:"expand,omitempty"`
+
+	// Strict Fail with 502 when any provider could not be queried instead of returning partial results
+	Strict *bool `form:"strict,omitempty" json:"strict,omitempty"`
+}
+
+// GetRoutesParamsCodeShare defines parameters for GetRoutes.
+type GetRoutesParamsCodeShare string
+
+// GetRoutesParamsExpand defines parameters for GetRoutes.
+type GetRoutesParamsExpand string
+
+// ListAirlinesParams defines parameters for ListAirlines.
+type ListAirlinesParams struct {
+	// Active Keep only airlines that are operating (true) or that ceased operations (false)
+	Active *bool `form:"active,omitempty" json:"active,omitempty"`
+
+	// Alliance Keep only members of the alliance
+	Alliance *ListAirlinesParamsAlliance `form:"alliance,omitempty" json:"alliance,omitempty"`
+}
+
+// ListAirlinesParamsAlliance defines parameters for ListAirlines.
+type ListAirlinesParamsAlliance string
+
+// SearchAirportsParams defines parameters for SearchAirports.
+type SearchAirportsParams struct {
+	// Q Text to search for
+	Q string `form:"q" json:"q"`
+
+	// Limit Maximum number of airports to return
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
 }
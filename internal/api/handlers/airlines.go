@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"flight-booking/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type AirlineHandler struct {
+	airlineService usecases.Airlines
+	logger         logger.Logger
+}
+
+// NewAirlineHandler creates a new airline handler.
+func NewAirlineHandler(airlineService usecases.Airlines, logger logger.Logger) *AirlineHandler {
+	return &AirlineHandler{
+		airlineService: airlineService,
+		logger:         logger.With("component", "airline_handler"),
+	}
+}
+
+// GetAirline implements the GetAirline method from ServerInterface.
+func (h *AirlineHandler) GetAirline(c *gin.Context, code string) {
+	airline, err := h.airlineService.Get(code)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToAPIAirline(airline))
+}
+
+// ListAirlines implements the ListAirlines method from ServerInterface.
+func (h *AirlineHandler) ListAirlines(c *gin.Context, params gen.ListAirlinesParams) {
+	filters := models.AirlineFilters{Active: params.Active}
+	if params.Alliance != nil {
+		filters.Alliance = string(*params.Alliance)
+	}
+
+	airlines := h.airlineService.List(filters)
+
+	apiAirlines := make([]gen.Airline, len(airlines))
+	for i, airline := range airlines {
+		apiAirlines[i] = convertToAPIAirline(airline)
+	}
+
+	c.JSON(http.StatusOK, gen.AirlinesResponse{Data: apiAirlines})
+}
+
+func convertToAPIAirline(airline models.Airline) gen.Airline {
+	apiAirline := gen.Airline{
+		Iata:    airline.IATA,
+		Name:    airline.Name,
+		Country: airline.Country,
+		Active:  airline.Active,
+	}
+
+	if airline.ICAO != "" {
+		apiAirline.Icao = &airline.ICAO
+	}
+
+	if airline.Callsign != "" {
+		apiAirline.Callsign = &airline.Callsign
+	}
+
+	if airline.Alliance != "" {
+		alliance := gen.AirlineAlliance(airline.Alliance)
+		apiAirline.Alliance = &alliance
+	}
+
+	return apiAirline
+}
+
+// expandAirlines embeds the catalog entries of the airlines into routes.
+// Airlines missing from the catalog are left out.
+func expandAirlines(routes []gen.FlightRoute, airlineService usecases.Airlines) {
+	for i := range routes {
+		airline, err := airlineService.Get(routes[i].Airline)
+		if err != nil {
+			continue
+		}
+
+		apiAirline := convertToAPIAirline(airline)
+		routes[i].AirlineDetails = &apiAirline
+	}
+}
+
+// unknownAirlines reports every code of the parameter that is neither in the
+// airline catalog nor operating a route of the latest provider data.
+func unknownAirlines(param string, codes []string, airlineService usecases.Airlines) []apperrors.FieldError {
+	var unknown []apperrors.FieldError
+
+	for _, code := range codes {
+		if !airlineService.Known(code) {
+			unknown = append(unknown, apperrors.FieldError{
+				Field:   param,
+				Message: fmt.Sprintf("must be a known airline, got %q", code),
+			})
+		}
+	}
+
+	return unknown
+}
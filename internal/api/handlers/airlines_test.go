@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"flight-booking/internal/services/reference"
+	"flight-booking/internal/services/routestore"
+	"flight-booking/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSnapshots struct {
+	store *routestore.Store
+}
+
+func (s staticSnapshots) Current() *routestore.Store {
+	return s.store
+}
+
+// testAirlines is a catalog of the airlines used across the handler tests. UT
+// is missing from it but operates a route of the provider data.
+var testAirlines = func() usecases.Airlines {
+	catalog, err := reference.ParseAirlines(strings.NewReader(`` +
+		`1,"American Airlines",\N,"AA","AAL","AMERICAN","United States","Y"` + "\n" +
+		`2,"British Airways",\N,"BA","BAW","SPEEDBIRD","United Kingdom","Y"` + "\n" +
+		`3,"Delta Air Lines",\N,"DL","DAL","DELTA","United States","Y"` + "\n" +
+		`4,"Ryanair",\N,"FR","RYR","RYANAIR","Ireland","Y"` + "\n" +
+		`5,"Lufthansa",\N,"LH","DLH","LUFTHANSA","Germany","Y"` + "\n" +
+		`6,"United Airlines",\N,"UA","UAL","UNITED","United States","Y"` + "\n" +
+		`7,"Continental Airlines",\N,"CO","COA",\N,"United States","N"` + "\n",
+	))
+	if err != nil {
+		panic(err)
+	}
+
+	return usecases.NewAirlines(catalog, staticSnapshots{store: routestore.New([]models.Route{
+		{Airline: "UT", SourceAirport: "DME", DestinationAirport: "SGC", CodeShare: "N"},
+	})})
+}()
+
+func serveAirlines(t *testing.T, target string) (*httptest.ResponseRecorder, []*gin.Error) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	var errs []*gin.Error
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	gen.RegisterHandlers(engine, testHandlers{AirlineHandler: NewAirlineHandler(testAirlines, logger.Context(t.Context()))})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return recorder, errs
+}
+
+func TestAirlineHandler_GetAirline(t *testing.T) {
+	t.Parallel()
+
+	recorder, _ := serveAirlines(t, "/api/v1/airlines/BA")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var airline gen.Airline
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &airline))
+
+	icao, callsign, alliance := "BAW", "SPEEDBIRD", gen.AirlineAllianceOneworld
+	assert.Equal(t, gen.Airline{
+		Iata: "BA", Icao: &icao, Name: "British Airways", Callsign: &callsign, Country: "United Kingdom",
+		Active: true, Alliance: &alliance,
+	}, airline)
+}
+
+func TestAirlineHandler_GetAirline_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, errs := serveAirlines(t, "/api/v1/airlines/ZZ")
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, apperrors.NotFound, apperrors.As(errs[0].Err).Code)
+}
+
+func TestAirlineHandler_ListAirlines(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{name: "every airline", target: "/api/v1/airlines", want: []string{"AA", "BA", "CO", "DL", "FR", "LH", "UA"}},
+		{name: "active", target: "/api/v1/airlines?active=true", want: []string{"AA", "BA", "DL", "FR", "LH", "UA"}},
+		{name: "inactive", target: "/api/v1/airlines?active=false", want: []string{"CO"}},
+		{name: "alliance", target: "/api/v1/airlines?alliance=star_alliance", want: []string{"LH", "UA"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder, _ := serveAirlines(t, tt.target)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var response gen.AirlinesResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+			codes := make([]string, len(response.Data))
+			for i, airline := range response.Data {
+				codes[i] = airline.Iata
+			}
+
+			assert.Equal(t, tt.want, codes)
+		})
+	}
+}
+
+func TestRouteHandler_GetRoutes_RejectsUnknownAirlines(t *testing.T) {
+	t.Parallel()
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		t.Error("Routes should not be queried for unknown airlines")
+
+		return models.RoutesResult{}, nil
+	})
+
+	gin.SetMode(gin.TestMode)
+
+	var errs []*gin.Error
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	gen.RegisterHandlers(engine, testHandlers{
+		RouteHandler: NewRouteHandler(routes, testAirports, testAirlines, logger.Context(t.Context())),
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/routes?airline=AA,XQ&excludeAirline=ZZ", nil))
+
+	require.Len(t, errs, 1)
+
+	appErr := apperrors.As(errs[0].Err)
+	assert.Equal(t, apperrors.Validation, appErr.Code)
+	assert.Equal(t, []apperrors.FieldError{
+		{Field: "airline", Message: `must be a known airline, got "XQ"`},
+		{Field: "excludeAirline", Message: `must be a known airline, got "ZZ"`},
+	}, appErr.Fields)
+}
+
+func TestRouteHandler_GetRoutes_ExpandAirlines(t *testing.T) {
+	t.Parallel()
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		return models.RoutesResult{
+			Routes: []models.Route{
+				{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "FRA", CodeShare: "N"},
+				{Airline: "9X", SourceAirport: "JFK", DestinationAirport: "FRA", CodeShare: "N"},
+			},
+			Total: 2,
+		}, nil
+	})
+
+	response := getRoutes(t, routes, "/api/v1/routes?expand=airlines,airports")
+	require.Len(t, response.Data, 2)
+	require.NotNil(t, response.Data[0].AirlineDetails)
+	assert.Equal(t, "United Airlines", response.Data[0].AirlineDetails.Name)
+	assert.NotNil(t, response.Data[0].SourceAirportDetails)
+	assert.Nil(t, response.Data[1].AirlineDetails, "Airlines missing from the catalog should be left out")
+}
+
+func TestRouteHandler_GetRoutes_AcceptsAirlinesOfProviderData(t *testing.T) {
+	t.Parallel()
+
+	var got models.RouteFilters
+
+	routes := routesFunc(func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+		got = filters
+
+		return models.RoutesResult{}, nil
+	})
+
+	getRoutes(t, routes, "/api/v1/routes?airline=UT&excludeAirline=UT")
+
+	assert.Equal(t, []string{"UT"}, got.Airlines, "Airlines missing from the catalog but flying routes should be accepted")
+	assert.Equal(t, []string{"UT"}, got.ExcludeAirlines)
+}
@@ -0,0 +1,196 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"flight-booking/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type AirportHandler struct {
+	airportService usecases.Airports
+	logger         logger.Logger
+}
+
+// NewAirportHandler creates a new airport handler.
+func NewAirportHandler(airportService usecases.Airports, logger logger.Logger) *AirportHandler {
+	return &AirportHandler{
+		airportService: airportService,
+		logger:         logger.With("component", "airport_handler"),
+	}
+}
+
+// GetAirport implements the GetAirport method from ServerInterface.
+func (h *AirportHandler) GetAirport(c *gin.Context, code string) {
+	airport, err := h.airportService.Get(code)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, convertToAPIAirport(airport))
+}
+
+// SearchAirports implements the SearchAirports method from ServerInterface.
+func (h *AirportHandler) SearchAirports(c *gin.Context, params gen.SearchAirportsParams) {
+	limit := models.DefaultAirportLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	airports := h.airportService.Search(params.Q, limit)
+
+	apiAirports := make([]gen.Airport, len(airports))
+	for i, airport := range airports {
+		apiAirports[i] = convertToAPIAirport(airport)
+	}
+
+	c.JSON(http.StatusOK, gen.AirportsResponse{Data: apiAirports})
+}
+
+func convertToAPIAirport(airport models.Airport) gen.Airport {
+	apiAirport := gen.Airport{
+		Iata:      airport.IATA,
+		Name:      airport.Name,
+		City:      airport.City,
+		Country:   airport.Country,
+		Latitude:  airport.Latitude,
+		Longitude: airport.Longitude,
+	}
+
+	if airport.ICAO != "" {
+		apiAirport.Icao = &airport.ICAO
+	}
+
+	if airport.Timezone != "" {
+		apiAirport.Timezone = &airport.Timezone
+	}
+
+	return apiAirport
+}
+
+// expandAirports embeds the catalog entries of the source and destination
+// airports into routes. Airports missing from the catalog are left out.
+func expandAirports(routes []gen.FlightRoute, airportService usecases.Airports) {
+	details := func(code string) *gen.Airport {
+		airport, err := airportService.Get(code)
+		if err != nil {
+			return nil
+		}
+
+		apiAirport := convertToAPIAirport(airport)
+
+		return &apiAirport
+	}
+
+	for i := range routes {
+		routes[i].SourceAirportDetails = details(routes[i].SourceAirport)
+		routes[i].DestinationAirportDetails = details(routes[i].DestinationAirport)
+	}
+}
+
+// expandMetros replaces the metropolitan area codes among codes with the
+// airports of the area.
+func expandMetros(codes []string, airportService usecases.Airports) []string {
+	expanded := make([]string, 0, len(codes))
+
+	for _, code := range codes {
+		if airports := airportService.Metro(code); airports != nil {
+			expanded = append(expanded, airports...)
+		} else {
+			expanded = append(expanded, code)
+		}
+	}
+
+	return expanded
+}
+
+// markMetros sets the metropolitan area codes among the requested source and
+// destination airports that routes matched through.
+func markMetros(routes []gen.FlightRoute, sources, destinations *[]string, airportService usecases.Airports) {
+	matched := func(codes *[]string, airport string) *string {
+		if codes == nil {
+			return nil
+		}
+
+		for _, code := range *codes {
+			if slices.Contains(airportService.Metro(code), airport) {
+				return &code
+			}
+		}
+
+		return nil
+	}
+
+	for i := range routes {
+		routes[i].SourceMetro = matched(sources, routes[i].SourceAirport)
+		routes[i].DestinationMetro = matched(destinations, routes[i].DestinationAirport)
+	}
+}
+
+// expands reports whether the expand parameter includes value.
+func expands[T ~string](expand *[]T, value T) bool {
+	return expand != nil && slices.Contains(*expand, value)
+}
+
+// nearbyArea is a circle given by latitude, longitude and radius query
+// parameters, which are only valid together.
+type nearbyArea struct {
+	lat, lon                        *float64
+	radiusKm                        *int
+	latParam, lonParam, radiusParam string
+	// exclusiveParam names the airport parameter the area replaces.
+	exclusiveParam string
+}
+
+// airportCodes returns the codes of the catalog airports within the area, an
+// empty list when there are none, or nil when none of its parameters are set.
+func (a nearbyArea) airportCodes(
+	airportService usecases.Airports,
+	exclusive *[]string,
+) ([]string, []apperrors.FieldError) {
+	if a.lat == nil && a.lon == nil && a.radiusKm == nil {
+		return nil, nil
+	}
+
+	var invalid []apperrors.FieldError
+
+	required := func(param string, set bool, with, and string) {
+		if !set {
+			invalid = append(invalid, apperrors.FieldError{
+				Field:   param,
+				Message: fmt.Sprintf("is required with %s and %s", with, and),
+			})
+		}
+	}
+	required(a.latParam, a.lat != nil, a.lonParam, a.radiusParam)
+	required(a.lonParam, a.lon != nil, a.latParam, a.radiusParam)
+	required(a.radiusParam, a.radiusKm != nil, a.latParam, a.lonParam)
+
+	if exclusive != nil {
+		invalid = append(invalid, apperrors.FieldError{
+			Field:   a.radiusParam,
+			Message: "cannot be combined with " + a.exclusiveParam,
+		})
+	}
+
+	if len(invalid) > 0 {
+		return nil, invalid
+	}
+
+	airports := airportService.Within(*a.lat, *a.lon, float64(*a.radiusKm))
+
+	codes := make([]string, len(airports))
+	for i, airport := range airports {
+		codes[i] = airport.IATA
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"flight-booking/internal/services/reference"
+	"flight-booking/internal/usecases"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testAirports is a catalog of the airports used across the handler tests.
+var testAirports = func() usecases.Airports {
+	catalog, err := reference.ParseAirports(strings.NewReader(`` +
+		`1,"John F Kennedy International Airport","New York","United States","JFK","KJFK",40.63980103,-73.77890015,13,-5,"A","America/New_York","airport","OurAirports"` + "\n" +
+		`2,"Frankfurt am Main Airport","Frankfurt","Germany","FRA","EDDF",50.036249,8.559294,364,1,"E","Europe/Berlin","airport","OurAirports"` + "\n" +
+		`3,"Newark Liberty International Airport","Newark","United States","EWR",\N,40.692501068115234,-74.168701171875,18,-5,"A",\N,"airport","OurAirports"` + "\n",
+	))
+	if err != nil {
+		panic(err)
+	}
+
+	return usecases.NewAirports(catalog)
+}()
+
+func serveAirports(t *testing.T, target string) (*httptest.ResponseRecorder, []*gin.Error) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	var errs []*gin.Error
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	gen.RegisterHandlers(engine, testHandlers{AirportHandler: NewAirportHandler(testAirports, logger.Context(t.Context()))})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return recorder, errs
+}
+
+func TestAirportHandler_GetAirport(t *testing.T) {
+	t.Parallel()
+
+	recorder, _ := serveAirports(t, "/api/v1/airports/FRA")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var airport gen.Airport
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &airport))
+
+	timezone, icao := "Europe/Berlin", "EDDF"
+	assert.Equal(t, gen.Airport{
+		Iata: "FRA", Icao: &icao, Name: "Frankfurt am Main Airport", City: "Frankfurt", Country: "Germany",
+		Latitude: 50.036249, Longitude: 8.559294, Timezone: &timezone,
+	}, airport)
+}
+
+func TestAirportHandler_GetAirport_OmitsMissingFields(t *testing.T) {
+	t.Parallel()
+
+	recorder, _ := serveAirports(t, "/api/v1/airports/EWR")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	assert.NotContains(t, recorder.Body.String(), "icao")
+	assert.NotContains(t, recorder.Body.String(), "timezone")
+}
+
+func TestAirportHandler_GetAirport_NotFound(t *testing.T) {
+	t.Parallel()
+
+	_, errs := serveAirports(t, "/api/v1/airports/XXX")
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, apperrors.NotFound, apperrors.As(errs[0].Err).Code)
+}
+
+func TestAirportHandler_SearchAirports(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target string
+		want   []string
+	}{
+		{name: "code first", target: "/api/v1/airports?q=ewr", want: []string{"EWR"}},
+		{name: "country", target: "/api/v1/airports?q=united%20states", want: []string{"EWR", "JFK"}},
+		{name: "limit", target: "/api/v1/airports?q=united%20states&limit=1", want: []string{"EWR"}},
+		{name: "no match", target: "/api/v1/airports?q=paris", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder, _ := serveAirports(t, tt.target)
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var response gen.AirportsResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+			codes := make([]string, len(response.Data))
+			for i, airport := range response.Data {
+				codes[i] = airport.Iata
+			}
+
+			assert.Equal(t, tt.want, codes)
+		})
+	}
+}
+
+func TestRouteHandler_GetRoutes_ExpandAirports(t *testing.T) {
+	t.Parallel()
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		return models.RoutesResult{
+			Routes: []models.Route{{Airline: "LH", SourceAirport: "JFK", DestinationAirport: "XXX", CodeShare: "N"}},
+			Total:  1,
+		}, nil
+	})
+
+	response := getRoutes(t, routes, "/api/v1/routes?expand=airports")
+	require.Len(t, response.Data, 1)
+	require.NotNil(t, response.Data[0].SourceAirportDetails)
+	assert.Equal(t, "New York", response.Data[0].SourceAirportDetails.City)
+	assert.Nil(t, response.Data[0].DestinationAirportDetails, "Airports missing from the catalog should be left out")
+
+	response = getRoutes(t, routes, "/api/v1/routes")
+	require.Len(t, response.Data, 1)
+	assert.Nil(t, response.Data[0].SourceAirportDetails)
+}
+
+func TestItineraryHandler_GetItineraries_ExpandAirports(t *testing.T) {
+	t.Parallel()
+
+	search := searchFunc(func(context.Context, models.ItineraryQuery) (models.ItinerariesResult, error) {
+		return models.ItinerariesResult{
+			Itineraries: []models.Itinerary{{
+				Legs: []models.Route{{Airline: "LH", SourceAirport: "JFK", DestinationAirport: "FRA", CodeShare: "N"}},
+			}},
+		}, nil
+	})
+
+	recorder, _ := serveItineraries(t, search, "/api/v1/itineraries?sourceAirport=JFK&destinationAirport=FRA&expand=airports")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response gen.ItinerariesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	require.Len(t, response.Data, 1)
+	leg := response.Data[0].Legs[0]
+	require.NotNil(t, leg.SourceAirportDetails)
+	require.NotNil(t, leg.DestinationAirportDetails)
+	assert.Equal(t, "Frankfurt", leg.DestinationAirportDetails.City)
+}
@@ -0,0 +1,69 @@
+package handlers
+
+import (
+	"net/http"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"flight-booking/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type AutocompleteHandler struct {
+	autocompleteService usecases.Autocomplete
+	logger              logger.Logger
+}
+
+// NewAutocompleteHandler creates a new autocomplete handler.
+func NewAutocompleteHandler(autocompleteService usecases.Autocomplete, logger logger.Logger) *AutocompleteHandler {
+	return &AutocompleteHandler{
+		autocompleteService: autocompleteService,
+		logger:              logger.With("component", "autocomplete_handler"),
+	}
+}
+
+// Autocomplete implements the Autocomplete method from ServerInterface.
+func (h *AutocompleteHandler) Autocomplete(c *gin.Context, params gen.AutocompleteParams) {
+	limit := models.DefaultSuggestionLimit
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+
+	suggestions, err := h.autocompleteService.Suggest(c.Request.Context(), params.Q, limit)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	apiSuggestions := make([]gen.Suggestion, len(suggestions))
+	for i, suggestion := range suggestions {
+		apiSuggestions[i] = convertToAPISuggestion(suggestion)
+	}
+
+	c.JSON(http.StatusOK, gen.SuggestionsResponse{Data: apiSuggestions})
+}
+
+func convertToAPISuggestion(suggestion models.Suggestion) gen.Suggestion {
+	apiSuggestion := gen.Suggestion{
+		Type:       gen.SuggestionType(suggestion.Kind),
+		Code:       suggestion.Code,
+		Name:       suggestion.Name,
+		RouteCount: suggestion.RouteCount,
+	}
+
+	if suggestion.City != "" {
+		apiSuggestion.City = &suggestion.City
+	}
+
+	if suggestion.Country != "" {
+		apiSuggestion.Country = &suggestion.Country
+	}
+
+	if suggestion.Airports != nil {
+		apiSuggestion.Airports = &suggestion.Airports
+	}
+
+	return apiSuggestion
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type suggestFunc func(ctx context.Context, query string, limit int) ([]models.Suggestion, error)
+
+func (f suggestFunc) Suggest(ctx context.Context, query string, limit int) ([]models.Suggestion, error) {
+	return f(ctx, query, limit)
+}
+
+func TestAutocompleteHandler_Autocomplete(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name      string
+		target    string
+		wantLimit int
+	}{
+		{name: "default limit", target: "/api/v1/autocomplete?q=lond", wantLimit: models.DefaultSuggestionLimit},
+		{name: "limit", target: "/api/v1/autocomplete?q=lond&limit=3", wantLimit: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			suggest := suggestFunc(func(_ context.Context, query string, limit int) ([]models.Suggestion, error) {
+				assert.Equal(t, "lond", query)
+				assert.Equal(t, tt.wantLimit, limit)
+
+				return []models.Suggestion{
+					{
+						Kind: models.SuggestionMetro, Code: "LON", Name: "London", City: "London", Country: "United Kingdom",
+						Airports: []string{"LHR", "LGW"}, RouteCount: 12,
+					},
+					{Kind: models.SuggestionAirport, Code: "LHR", Name: "London Heathrow Airport", City: "London", RouteCount: 10},
+				}, nil
+			})
+
+			engine := gin.New()
+			gen.RegisterHandlers(engine, testHandlers{
+				AutocompleteHandler: NewAutocompleteHandler(suggest, logger.Context(t.Context())),
+			})
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.target, nil))
+			require.Equal(t, http.StatusOK, recorder.Code)
+
+			var response gen.SuggestionsResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+			require.Len(t, response.Data, 2)
+			assert.Equal(t, gen.SuggestionTypeMetro, response.Data[0].Type)
+			require.NotNil(t, response.Data[0].Airports)
+			assert.Equal(t, []string{"LHR", "LGW"}, *response.Data[0].Airports)
+			assert.Equal(t, 12, response.Data[0].RouteCount)
+			assert.Equal(t, gen.SuggestionTypeAirport, response.Data[1].Type)
+			assert.Nil(t, response.Data[1].Airports)
+			assert.Nil(t, response.Data[1].Country)
+		})
+	}
+}
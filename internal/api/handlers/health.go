@@ -3,15 +3,29 @@ package handlers
 import (
 	"net/http"
 
+	"flight-booking/internal/config"
+	"flight-booking/internal/services/providers"
 	"github.com/gin-gonic/gin"
 )
 
-type HealthHandler struct{}
+type HealthHandler struct {
+	warmer         *providers.Warmer
+	blockReadiness bool
+}
 
-func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+func NewHealthHandler(warmer *providers.Warmer, config config.Config) *HealthHandler {
+	return &HealthHandler{
+		warmer:         warmer,
+		blockReadiness: config.Warmer.BlockReadiness,
+	}
 }
 
 func (h *HealthHandler) HealthCheck(c *gin.Context) {
+	if h.blockReadiness && !h.warmer.Warm() {
+		c.JSON(http.StatusServiceUnavailable, map[string]string{"status": "WARMING_UP"})
+
+		return
+	}
+
 	c.JSON(http.StatusOK, map[any]any{})
 }
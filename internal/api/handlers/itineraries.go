@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"net/http"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"flight-booking/internal/usecases"
+	"github.com/gin-gonic/gin"
+)
+
+type ItineraryHandler struct {
+	itineraryService usecases.Itineraries
+	airportService   usecases.Airports
+	airlineService   usecases.Airlines
+	logger           logger.Logger
+}
+
+// NewItineraryHandler creates a new itinerary handler.
+func NewItineraryHandler(
+	itineraryService usecases.Itineraries,
+	airportService usecases.Airports,
+	airlineService usecases.Airlines,
+	logger logger.Logger,
+) *ItineraryHandler {
+	return &ItineraryHandler{
+		itineraryService: itineraryService,
+		airportService:   airportService,
+		airlineService:   airlineService,
+		logger:           logger.With("component", "itinerary_handler"),
+	}
+}
+
+// GetItineraries implements the GetItineraries method from ServerInterface.
+func (h *ItineraryHandler) GetItineraries(c *gin.Context, params gen.GetItinerariesParams) {
+	query, err := h.convertParamsToQuery(params)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	result, err := h.itineraryService.Search(c.Request.Context(), query)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
+
+	itineraries := make([]gen.Itinerary, len(result.Itineraries))
+
+	for i, itinerary := range result.Itineraries {
+		legs := make([]gen.FlightRoute, len(itinerary.Legs))
+		for j, leg := range itinerary.Legs {
+			legs[j] = convertToAPIRoute(leg)
+		}
+
+		if expands(params.Expand, gen.GetItinerariesParamsExpandAirports) {
+			expandAirports(legs, h.airportService)
+		}
+
+		if expands(params.Expand, gen.GetItinerariesParamsExpandAirlines) {
+			expandAirlines(legs, h.airlineService)
+		}
+
+		itineraries[i] = gen.Itinerary{
+			Legs:                 legs,
+			MinConnectionMinutes: int(itinerary.MinConnectionTime.Minutes()),
+		}
+	}
+
+	c.JSON(http.StatusOK, gen.ItinerariesResponse{
+		Data: itineraries,
+		Meta: convertToAPIMeta(result.Providers),
+	})
+}
+
+func (h *ItineraryHandler) convertParamsToQuery(params gen.GetItinerariesParams) (models.ItineraryQuery, error) {
+	query := models.ItineraryQuery{
+		SourceAirport:      params.SourceAirport,
+		DestinationAirport: params.DestinationAirport,
+		MaxLegs:            models.DefaultMaxLegs,
+		Carrier:            models.CarrierAny,
+		Limit:              models.DefaultItineraryLimit,
+	}
+
+	if query.SourceAirport == query.DestinationAirport {
+		return query, apperrors.New(apperrors.Validation, "Invalid request parameters",
+			apperrors.FieldError{Field: "destinationAirport", Message: "must differ from sourceAirport"})
+	}
+
+	if params.MaxLegs != nil {
+		query.MaxLegs = *params.MaxLegs
+	}
+
+	if params.Carrier != nil {
+		query.Carrier = models.Carrier(*params.Carrier)
+	}
+
+	if params.Limit != nil {
+		query.Limit = *params.Limit
+	}
+
+	return query, nil
+}
@@ -0,0 +1,134 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type searchFunc func(ctx context.Context, query models.ItineraryQuery) (models.ItinerariesResult, error)
+
+func (f searchFunc) Search(ctx context.Context, query models.ItineraryQuery) (models.ItinerariesResult, error) {
+	return f(ctx, query)
+}
+
+func serveItineraries(t *testing.T, search searchFunc, target string) (*httptest.ResponseRecorder, []*gin.Error) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	var errs []*gin.Error
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	gen.RegisterHandlers(engine, testHandlers{ItineraryHandler: NewItineraryHandler(search, testAirports, testAirlines, logger.Context(t.Context()))})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+
+	return recorder, errs
+}
+
+func TestItineraryHandler_GetItineraries(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		target string
+		want   models.ItineraryQuery
+	}{
+		{
+			name:   "defaults",
+			target: "/api/v1/itineraries?sourceAirport=JFK&destinationAirport=SIN",
+			want: models.ItineraryQuery{
+				SourceAirport: "JFK", DestinationAirport: "SIN",
+				MaxLegs: models.DefaultMaxLegs, Carrier: models.CarrierAny, Limit: models.DefaultItineraryLimit,
+			},
+		},
+		{
+			name:   "every parameter",
+			target: "/api/v1/itineraries?sourceAirport=JFK&destinationAirport=SIN&maxLegs=3&carrier=alliance&limit=5",
+			want: models.ItineraryQuery{
+				SourceAirport: "JFK", DestinationAirport: "SIN", MaxLegs: 3, Carrier: models.CarrierAlliance, Limit: 5,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got models.ItineraryQuery
+
+			search := searchFunc(func(_ context.Context, query models.ItineraryQuery) (models.ItinerariesResult, error) {
+				got = query
+
+				return models.ItinerariesResult{}, nil
+			})
+
+			recorder, _ := serveItineraries(t, search, tt.target)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestItineraryHandler_GetItineraries_Response(t *testing.T) {
+	t.Parallel()
+
+	search := searchFunc(func(context.Context, models.ItineraryQuery) (models.ItinerariesResult, error) {
+		return models.ItinerariesResult{
+			Itineraries: []models.Itinerary{{
+				Legs: []models.Route{
+					{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "FRA", CodeShare: "N"},
+					{Airline: "LH", SourceAirport: "FRA", DestinationAirport: "SIN", CodeShare: "N"},
+				},
+				MinConnectionTime: 90 * time.Minute,
+			}},
+			Providers: models.ProviderReports{{Name: "provider1", Status: models.ProviderStatusStale}},
+		}, nil
+	})
+
+	recorder, _ := serveItineraries(t, search, "/api/v1/itineraries?sourceAirport=JFK&destinationAirport=SIN")
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response gen.ItinerariesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	require.Len(t, response.Data, 1)
+	assert.Equal(t, 90, response.Data[0].MinConnectionMinutes)
+	require.Len(t, response.Data[0].Legs, 2)
+	assert.Equal(t, "FRA", response.Data[0].Legs[0].DestinationAirport)
+	assert.Equal(t, "LH", response.Data[0].Legs[1].Airline)
+	assert.True(t, response.Meta.Stale)
+}
+
+func TestItineraryHandler_GetItineraries_SameAirports(t *testing.T) {
+	t.Parallel()
+
+	search := searchFunc(func(context.Context, models.ItineraryQuery) (models.ItinerariesResult, error) {
+		t.Error("Itineraries should not be searched between the same airports")
+
+		return models.ItinerariesResult{}, nil
+	})
+
+	_, errs := serveItineraries(t, search, "/api/v1/itineraries?sourceAirport=JFK&destinationAirport=JFK")
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, apperrors.Validation, apperrors.As(errs[0].Err).Code)
+}
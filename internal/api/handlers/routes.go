@@ -1,9 +1,17 @@
 package handlers
 
 import (
+	"context"
+	"errors"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
 	"flight-booking/internal/models"
 	"flight-booking/internal/services/logger"
 	"flight-booking/internal/usecases"
@@ -11,22 +19,37 @@ import (
 )
 
 type RouteHandler struct {
-	routeService usecases.Routes
-	logger       logger.Logger
+	routeService   usecases.Routes
+	airportService usecases.Airports
+	airlineService usecases.Airlines
+	logger         logger.Logger
 }
 
 // NewRouteHandler creates a new route handler.
-func NewRouteHandler(routeService usecases.Routes, logger logger.Logger) *RouteHandler {
+func NewRouteHandler(
+	routeService usecases.Routes,
+	airportService usecases.Airports,
+	airlineService usecases.Airlines,
+	logger logger.Logger,
+) *RouteHandler {
 	return &RouteHandler{
-		routeService: routeService,
-		logger:       logger.With("component", "route_handler"),
+		routeService:   routeService,
+		airportService: airportService,
+		airlineService: airlineService,
+		logger:         logger.With("component", "route_handler"),
 	}
 }
 
 // GetRoutes implements the GetRoutes method from ServerInterface.
 func (h *RouteHandler) GetRoutes(c *gin.Context, params gen.GetRoutesParams) {
 	ctx := c.Request.Context()
-	filters := h.convertParamsToFilters(params)
+
+	filters, err := h.convertParamsToFilters(params)
+	if err != nil {
+		_ = c.Error(err)
+
+		return
+	}
 
 	response, err := h.routeService.GetRoutes(ctx, filters)
 	if err != nil {
@@ -35,28 +58,111 @@ func (h *RouteHandler) GetRoutes(c *gin.Context, params gen.GetRoutesParams) {
 		return
 	}
 
+	if params.Strict != nil && *params.Strict {
+		if failed := response.FailedProviders(); len(failed) > 0 {
+			_ = c.Error(apperrors.New(apperrors.UpstreamUnavailable, "Providers failed: "+strings.Join(failed, ", ")))
+
+			return
+		}
+	}
+
 	apiResponse := h.convertToAPIResponse(response)
+	markMetros(apiResponse.Data, params.SourceAirport, params.DestinationAirport, h.airportService)
+
+	if expands(params.Expand, gen.GetRoutesParamsExpandAirports) {
+		expandAirports(apiResponse.Data, h.airportService)
+	}
+
+	if expands(params.Expand, gen.GetRoutesParamsExpandAirlines) {
+		expandAirlines(apiResponse.Data, h.airlineService)
+	}
+
+	apiResponse.Pagination = h.convertToAPIPagination(c.Request.URL, filters, response)
 	c.JSON(http.StatusOK, apiResponse)
 }
 
-func (h *RouteHandler) convertParamsToFilters(params gen.GetRoutesParams) models.RouteFilters {
+func (h *RouteHandler) convertParamsToFilters(params gen.GetRoutesParams) (models.RouteFilters, error) {
 	filters := models.RouteFilters{}
 
 	if params.Airline != nil {
-		filters.Airline = *params.Airline
+		filters.Airlines = *params.Airline
+	}
+
+	if params.ExcludeAirline != nil {
+		filters.ExcludeAirlines = *params.ExcludeAirline
+	}
+
+	unknown := append(
+		unknownAirlines("airline", filters.Airlines, h.airlineService),
+		unknownAirlines("excludeAirline", filters.ExcludeAirlines, h.airlineService)...,
+	)
+	if len(unknown) > 0 {
+		return filters, apperrors.New(apperrors.Validation, "Invalid request parameters", unknown...)
 	}
 
 	if params.SourceAirport != nil {
-		filters.SourceAirport = *params.SourceAirport
+		filters.SourceAirports = expandMetros(*params.SourceAirport, h.airportService)
 	}
 
 	if params.DestinationAirport != nil {
-		filters.DestinationAirport = *params.DestinationAirport
+		filters.DestinationAirports = expandMetros(*params.DestinationAirport, h.airportService)
+	}
+
+	sourceArea := nearbyArea{
+		lat: params.NearLat, lon: params.NearLon, radiusKm: params.RadiusKm,
+		latParam: "nearLat", lonParam: "nearLon", radiusParam: "radiusKm", exclusiveParam: "sourceAirport",
+	}
+	destinationArea := nearbyArea{
+		lat: params.DestinationNearLat, lon: params.DestinationNearLon, radiusKm: params.DestinationRadiusKm,
+		latParam: "destinationNearLat", lonParam: "destinationNearLon", radiusParam: "destinationRadiusKm",
+		exclusiveParam: "destinationAirport",
+	}
+
+	sources, invalid := sourceArea.airportCodes(h.airportService, params.SourceAirport)
+	destinations, invalidDestinations := destinationArea.airportCodes(h.airportService, params.DestinationAirport)
+
+	if invalid = append(invalid, invalidDestinations...); len(invalid) > 0 {
+		return filters, apperrors.New(apperrors.Validation, "Invalid request parameters", invalid...)
+	}
+
+	if sources != nil {
+		filters.SourceAirports = sources
+	}
+
+	if destinations != nil {
+		filters.DestinationAirports = destinations
+	}
+
+	if params.CodeShare != nil {
+		filters.CodeShare = string(*params.CodeShare)
+	}
+
+	if params.Equipment != nil {
+		filters.Equipment = *params.Equipment
 	}
 
 	if params.MaxStops != nil {
 		filters.MaxStops = params.MaxStops
 	}
+
+	filters.MinDistanceKm = params.MinDistanceKm
+	filters.MaxDistanceKm = params.MaxDistanceKm
+
+	if filters.MinDistanceKm != nil && filters.MaxDistanceKm != nil && *filters.MinDistanceKm > *filters.MaxDistanceKm {
+		return filters, apperrors.New(apperrors.Validation, "Invalid request parameters",
+			apperrors.FieldError{Field: "maxDistanceKm", Message: "must not be less than minDistanceKm"})
+	}
+	if params.Sort != nil {
+		sort, err := models.ParseSort(*params.Sort)
+		if err != nil {
+			return filters, apperrors.New(apperrors.Validation, "Invalid request parameters",
+				apperrors.FieldError{Field: "sort", Message: err.Error()})
+		}
+
+		filters.Sort = sort
+	}
+
+	filters.Limit = models.DefaultRouteLimit
 	if params.Limit != nil {
 		filters.Limit = *params.Limit
 	}
@@ -64,27 +170,144 @@ func (h *RouteHandler) convertParamsToFilters(params gen.GetRoutesParams) models
 		filters.Offset = *params.Offset
 	}
 
-	return filters
-}
+	if params.Cursor != nil {
+		if params.Offset != nil {
+			return filters, apperrors.New(apperrors.Validation, "Invalid request parameters",
+				apperrors.FieldError{Field: "cursor", Message: "cannot be combined with offset"})
+		}
 
-func (h *RouteHandler) convertToAPIResponse(routes []models.Route) *gen.RoutesResponse {
-	apiRoutes := make([]gen.FlightRoute, len(routes))
-
-	for i, route := range routes {
-		apiRoute := gen.FlightRoute{
-			Airline:            route.Airline,
-			SourceAirport:      route.SourceAirport,
-			DestinationAirport: route.DestinationAirport,
-			CodeShare:          gen.FlightRouteCodeShare(route.CodeShare),
-			Stops:              route.Stops,
-			Equipment:          route.Equipment,
-			Provider:           &route.Provider,
+		cursor, err := models.ParseCursor(*params.Cursor)
+		if err != nil {
+			return filters, apperrors.New(apperrors.Validation, "Invalid request parameters",
+				apperrors.FieldError{Field: "cursor", Message: "is not a cursor returned by this API"})
 		}
 
-		apiRoutes[i] = apiRoute
+		filters.Cursor = &cursor
+	}
+
+	return filters, nil
+}
+
+func (h *RouteHandler) convertToAPIResponse(result models.RoutesResult) *gen.RoutesResponse {
+	apiRoutes := make([]gen.FlightRoute, len(result.Routes))
+
+	for i, route := range result.Routes {
+		apiRoutes[i] = convertToAPIRoute(route)
 	}
 
 	return &gen.RoutesResponse{
 		Data: apiRoutes,
+		Meta: convertToAPIMeta(result.Providers),
+	}
+}
+
+func convertToAPIRoute(route models.Route) gen.FlightRoute {
+	apiRoute := gen.FlightRoute{
+		Airline:            route.Airline,
+		SourceAirport:      route.SourceAirport,
+		DestinationAirport: route.DestinationAirport,
+		CodeShare:          gen.FlightRouteCodeShare(route.CodeShare),
+		Stops:              route.Stops,
+		Equipment:          route.Equipment,
+		Provider:           &route.Provider,
+		Providers:          route.Providers,
+	}
+
+	if route.Estimate != nil {
+		distance := int(math.Round(route.Estimate.DistanceKm))
+		duration := int(route.Estimate.BlockTime.Round(time.Minute).Minutes())
+		apiRoute.DistanceKm = &distance
+		apiRoute.EstimatedDurationMinutes = &duration
+	}
+
+	return apiRoute
+}
+
+func convertToAPIMeta(reports models.ProviderReports) gen.RoutesMeta {
+	providers := make([]gen.ProviderMeta, len(reports))
+
+	for i, report := range reports {
+		providers[i] = gen.ProviderMeta{
+			Name:       report.Name,
+			Status:     gen.ProviderStatus(report.Status),
+			LatencyMs:  int(report.Latency.Milliseconds()),
+			RouteCount: report.RouteCount,
+		}
+
+		if report.Error != nil {
+			reason := providerErrorReason(report.Error)
+			providers[i].Error = &reason
+		}
+	}
+
+	return gen.RoutesMeta{
+		Providers: providers,
+		Stale:     reports.Stale(),
+	}
+}
+
+// providerErrorReason returns the reason a provider failed as reported to
+// clients. The error itself is only logged, as it may hold upstream URLs and
+// responses.
+func providerErrorReason(err error) string {
+	var timeout interface{ Timeout() bool }
+	if errors.Is(err, context.DeadlineExceeded) || errors.As(err, &timeout) && timeout.Timeout() {
+		return "timeout"
+	}
+
+	return "upstream error"
+}
+
+// convertToAPIPagination describes the page of result. The next and previous
+// page links repeat the request with only the position changed, as a cursor
+// into the same snapshot if the request used one and as an offset otherwise.
+func (h *RouteHandler) convertToAPIPagination(
+	requestURL *url.URL,
+	filters models.RouteFilters,
+	result models.RoutesResult,
+) gen.Pagination {
+	pagination := gen.Pagination{
+		Total:   result.Total,
+		Limit:   filters.Limit,
+		Offset:  result.Offset,
+		HasMore: result.Offset < result.Total-filters.Limit,
 	}
+
+	cursor := func(position int) *string {
+		if result.Snapshot == 0 {
+			return nil
+		}
+
+		encoded := models.Cursor{Snapshot: result.Snapshot, Position: position, Filters: filters.Fingerprint()}.Encode()
+
+		return &encoded
+	}
+
+	pageURL := func(position int) *string {
+		query := requestURL.Query()
+
+		if filters.Cursor != nil && result.Snapshot != 0 {
+			query.Set("cursor", *cursor(position))
+		} else {
+			query.Set("offset", strconv.Itoa(position))
+		}
+
+		link := (&url.URL{Path: requestURL.Path, RawQuery: query.Encode()}).String()
+
+		return &link
+	}
+
+	if pagination.HasMore {
+		next := result.Offset + filters.Limit
+		pagination.Next = pageURL(next)
+		pagination.NextCursor = cursor(next)
+	}
+
+	if result.Offset > 0 {
+		prev := max(result.Offset-filters.Limit, 0)
+		pagination.Prev = pageURL(prev)
+		pagination.PrevCursor = cursor(prev)
+	}
+
+	return pagination
 }
@@ -0,0 +1,479 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/logger"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testHandlers serves the handlers under test, leaving the others nil.
+type testHandlers struct {
+	*RouteHandler
+	*ItineraryHandler
+	*AirportHandler
+	*AirlineHandler
+	*AutocompleteHandler
+	*HealthHandler
+}
+
+type routesFunc func(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error)
+
+func (f routesFunc) GetRoutes(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+	return f(ctx, filters)
+}
+
+func getRoutes(t *testing.T, routes routesFunc, target string) gen.RoutesResponse {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	gen.RegisterHandlers(engine, testHandlers{RouteHandler: NewRouteHandler(routes, testAirports, testAirlines, logger.Context(t.Context()))})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, target, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+
+	var response gen.RoutesResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+
+	return response
+}
+
+func TestRouteHandler_GetRoutes_Pagination(t *testing.T) {
+	t.Parallel()
+
+	total := func(n int) routesFunc {
+		return func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+			return models.RoutesResult{Total: n, Offset: filters.Offset}, nil
+		}
+	}
+
+	link := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		total  int
+		target string
+		want   gen.Pagination
+	}{
+		{
+			name:   "defaults",
+			total:  250,
+			target: "/api/v1/routes",
+			want:   gen.Pagination{Total: 250, Limit: 100, HasMore: true, Next: link("/api/v1/routes?offset=100")},
+		},
+		{
+			name:   "middle page keeps the other parameters",
+			total:  250,
+			target: "/api/v1/routes?sourceAirport=JFK&limit=50&offset=60",
+			want: gen.Pagination{
+				Total: 250, Limit: 50, Offset: 60, HasMore: true,
+				Next: link("/api/v1/routes?limit=50&offset=110&sourceAirport=JFK"),
+				Prev: link("/api/v1/routes?limit=50&offset=10&sourceAirport=JFK"),
+			},
+		},
+		{
+			name:   "last page",
+			total:  250,
+			target: "/api/v1/routes?limit=100&offset=200",
+			want: gen.Pagination{
+				Total: 250, Limit: 100, Offset: 200,
+				Prev: link("/api/v1/routes?limit=100&offset=100"),
+			},
+		},
+		{
+			name:   "previous page does not go below zero",
+			total:  5,
+			target: "/api/v1/routes?limit=10&offset=3",
+			want:   gen.Pagination{Total: 5, Limit: 10, Offset: 3, Prev: link("/api/v1/routes?limit=10&offset=0")},
+		},
+		{
+			name:   "no routes",
+			target: "/api/v1/routes",
+			want:   gen.Pagination{Limit: 100},
+		},
+		{
+			name:   "largest offset",
+			total:  250,
+			target: "/api/v1/routes?offset=9223372036854775807",
+			want: gen.Pagination{
+				Total: 250, Limit: 100, Offset: math.MaxInt64,
+				Prev: link("/api/v1/routes?offset=9223372036854775707"),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			response := getRoutes(t, total(tt.total), tt.target)
+
+			assert.Equal(t, tt.want, response.Pagination)
+		})
+	}
+}
+
+func TestRouteHandler_GetRoutes_Filters(t *testing.T) {
+	t.Parallel()
+
+	var got models.RouteFilters
+
+	routes := routesFunc(func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+		got = filters
+
+		return models.RoutesResult{}, nil
+	})
+
+	getRoutes(t, routes, "/api/v1/routes?airline=AA,BA&excludeAirline=FR&sourceAirport=JFK,EWR&destinationAirport=LAX"+
+		"&codeShare=N&equipment=737,320&minDistanceKm=100&maxDistanceKm=5000")
+
+	assert.Equal(t, []string{"AA", "BA"}, got.Airlines)
+	assert.Equal(t, []string{"FR"}, got.ExcludeAirlines)
+	assert.Equal(t, []string{"JFK", "EWR"}, got.SourceAirports)
+	assert.Equal(t, []string{"LAX"}, got.DestinationAirports)
+	assert.Equal(t, "N", got.CodeShare)
+	assert.Equal(t, []string{"737", "320"}, got.Equipment)
+	require.NotNil(t, got.MinDistanceKm)
+	assert.Equal(t, 100, *got.MinDistanceKm)
+	require.NotNil(t, got.MaxDistanceKm)
+	assert.Equal(t, 5000, *got.MaxDistanceKm)
+}
+
+func TestRouteHandler_GetRoutes_Metros(t *testing.T) {
+	t.Parallel()
+
+	var got models.RouteFilters
+
+	routes := routesFunc(func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+		got = filters
+
+		return models.RoutesResult{
+			Routes: []models.Route{
+				{Airline: "BA", SourceAirport: "LHR", DestinationAirport: "JFK", CodeShare: "N"},
+				{Airline: "LH", SourceAirport: "FRA", DestinationAirport: "EWR", CodeShare: "N"},
+			},
+			Total: 2,
+		}, nil
+	})
+
+	response := getRoutes(t, routes, "/api/v1/routes?sourceAirport=LON,FRA&destinationAirport=NYC")
+
+	assert.Equal(t, []string{"LHR", "LGW", "STN", "LTN", "LCY", "SEN", "FRA"}, got.SourceAirports)
+	assert.Equal(t, []string{"JFK", "EWR", "LGA"}, got.DestinationAirports)
+
+	require.Len(t, response.Data, 2)
+	require.NotNil(t, response.Data[0].SourceMetro)
+	assert.Equal(t, "LON", *response.Data[0].SourceMetro)
+	require.NotNil(t, response.Data[0].DestinationMetro)
+	assert.Equal(t, "NYC", *response.Data[0].DestinationMetro)
+	assert.Nil(t, response.Data[1].SourceMetro, "FRA was requested as an airport")
+}
+
+func TestRouteHandler_GetRoutes_NearbyAirports(t *testing.T) {
+	t.Parallel()
+
+	var got models.RouteFilters
+
+	routes := routesFunc(func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+		got = filters
+
+		return models.RoutesResult{}, nil
+	})
+
+	getRoutes(t, routes, "/api/v1/routes?nearLat=40.71&nearLon=-74&radiusKm=50"+
+		"&destinationNearLat=50.11&destinationNearLon=8.68&destinationRadiusKm=20")
+
+	assert.Equal(t, []string{"EWR", "JFK"}, got.SourceAirports)
+	assert.Equal(t, []string{"FRA"}, got.DestinationAirports)
+
+	getRoutes(t, routes, "/api/v1/routes?nearLat=0&nearLon=-160&radiusKm=1000")
+
+	assert.NotNil(t, got.SourceAirports, "An area without airports should match no route")
+	assert.Empty(t, got.SourceAirports)
+}
+
+func TestRouteHandler_GetRoutes_RejectsInvalidAreas(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		t.Error("Routes should not be queried for an invalid area")
+
+		return models.RoutesResult{}, nil
+	})
+
+	tests := []struct {
+		name   string
+		target string
+		want   []apperrors.FieldError
+	}{
+		{
+			name:   "missing radius",
+			target: "/api/v1/routes?nearLat=40.71&nearLon=-74",
+			want:   []apperrors.FieldError{{Field: "radiusKm", Message: "is required with nearLat and nearLon"}},
+		},
+		{
+			name:   "missing coordinates",
+			target: "/api/v1/routes?destinationRadiusKm=50",
+			want: []apperrors.FieldError{
+				{Field: "destinationNearLat", Message: "is required with destinationNearLon and destinationRadiusKm"},
+				{Field: "destinationNearLon", Message: "is required with destinationNearLat and destinationRadiusKm"},
+			},
+		},
+		{
+			name:   "combined with airports",
+			target: "/api/v1/routes?sourceAirport=JFK&nearLat=40.71&nearLon=-74&radiusKm=50",
+			want:   []apperrors.FieldError{{Field: "radiusKm", Message: "cannot be combined with sourceAirport"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var errs []*gin.Error
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Next()
+				errs = c.Errors
+			})
+			gen.RegisterHandlers(engine, testHandlers{
+				RouteHandler: NewRouteHandler(routes, testAirports, testAirlines, logger.Context(t.Context())),
+			})
+
+			engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			require.Len(t, errs, 1)
+			appErr := apperrors.As(errs[0].Err)
+			assert.Equal(t, apperrors.Validation, appErr.Code)
+			assert.Equal(t, tt.want, appErr.Fields)
+		})
+	}
+}
+
+func TestRouteHandler_GetRoutes_Estimate(t *testing.T) {
+	t.Parallel()
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		return models.RoutesResult{
+			Routes: []models.Route{
+				{
+					Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "N",
+					Estimate: &models.FlightEstimate{DistanceKm: 3974.49, BlockTime: 5*time.Hour + 28*time.Minute + 20*time.Second},
+				},
+				{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "XXX", CodeShare: "N"},
+			},
+			Total: 2,
+		}, nil
+	})
+
+	response := getRoutes(t, routes, "/api/v1/routes")
+	require.Len(t, response.Data, 2)
+
+	require.NotNil(t, response.Data[0].DistanceKm)
+	assert.Equal(t, 3974, *response.Data[0].DistanceKm)
+	require.NotNil(t, response.Data[0].EstimatedDurationMinutes)
+	assert.Equal(t, 328, *response.Data[0].EstimatedDurationMinutes)
+
+	assert.Nil(t, response.Data[1].DistanceKm)
+	assert.Nil(t, response.Data[1].EstimatedDurationMinutes)
+}
+
+func TestRouteHandler_GetRoutes_RejectsInvertedDistanceRange(t *testing.T) {
+	t.Parallel()
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		t.Error("Routes should not be queried for an empty distance range")
+
+		return models.RoutesResult{}, nil
+	})
+
+	gin.SetMode(gin.TestMode)
+
+	var errs []*gin.Error
+
+	engine := gin.New()
+	engine.Use(func(c *gin.Context) {
+		c.Next()
+		errs = c.Errors
+	})
+	gen.RegisterHandlers(engine, testHandlers{
+		RouteHandler: NewRouteHandler(routes, testAirports, testAirlines, logger.Context(t.Context())),
+	})
+
+	engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/v1/routes?minDistanceKm=500&maxDistanceKm=100", nil))
+
+	require.Len(t, errs, 1)
+	assert.Equal(t, apperrors.Validation, apperrors.As(errs[0].Err).Code)
+}
+
+func TestRouteHandler_GetRoutes_CursorLinks(t *testing.T) {
+	t.Parallel()
+
+	routes := routesFunc(func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+		require.NotNil(t, filters.Cursor)
+
+		return models.RoutesResult{Total: 30, Offset: filters.Cursor.Position, Snapshot: filters.Cursor.Snapshot}, nil
+	})
+
+	filters := models.RouteFilters{Airlines: []string{"AA"}}
+	cursor := models.Cursor{Snapshot: 42, Position: 10, Filters: filters.Fingerprint()}
+
+	response := getRoutes(t, routes, "/api/v1/routes?airline=AA&limit=10&cursor="+cursor.Encode())
+	pagination := response.Pagination
+
+	assert.Equal(t, 10, pagination.Offset)
+	require.NotNil(t, pagination.NextCursor)
+	require.NotNil(t, pagination.PrevCursor)
+
+	next, err := models.ParseCursor(*pagination.NextCursor)
+	require.NoError(t, err)
+	assert.Equal(t, models.Cursor{Snapshot: 42, Position: 20, Filters: filters.Fingerprint()}, next)
+
+	prev, err := models.ParseCursor(*pagination.PrevCursor)
+	require.NoError(t, err)
+	assert.Equal(t, 0, prev.Position)
+
+	assert.Equal(t, "/api/v1/routes?airline=AA&cursor="+*pagination.NextCursor+"&limit=10", *pagination.Next)
+}
+
+func TestRouteHandler_GetRoutes_RejectsInvalidCursors(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		t.Error("Routes should not be queried with an invalid cursor")
+
+		return models.RoutesResult{}, nil
+	})
+
+	cursor := models.Cursor{Snapshot: 42, Filters: models.RouteFilters{}.Fingerprint()}.Encode()
+
+	for _, target := range []string{
+		"/api/v1/routes?cursor=garbage",
+		"/api/v1/routes?offset=10&cursor=" + cursor,
+	} {
+		var errs []*gin.Error
+
+		engine := gin.New()
+		engine.Use(func(c *gin.Context) {
+			c.Next()
+			errs = c.Errors
+		})
+		gen.RegisterHandlers(engine, testHandlers{RouteHandler: NewRouteHandler(routes, testAirports, testAirlines, logger.Context(t.Context()))})
+
+		engine.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, target, nil))
+
+		require.Len(t, errs, 1, target)
+		assert.Equal(t, apperrors.Validation, apperrors.As(errs[0].Err).Code, target)
+	}
+}
+
+func TestRouteHandler_GetRoutes_Strict(t *testing.T) {
+	t.Parallel()
+
+	gin.SetMode(gin.TestMode)
+
+	upstreamErr := apperrors.New(apperrors.UpstreamUnavailable,
+		"provider2 request failed: <html>secret.internal:8443 stack trace</html>")
+
+	tests := []struct {
+		name     string
+		report   models.ProviderReport
+		wantCode apperrors.Code
+	}{
+		{
+			name:     "failed provider",
+			report:   models.ProviderReport{Name: "provider2", Status: models.ProviderStatusFailed, Error: upstreamErr},
+			wantCode: apperrors.UpstreamUnavailable,
+		},
+		{
+			name:   "stale provider",
+			report: models.ProviderReport{Name: "provider2", Status: models.ProviderStatusStale},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+				return models.RoutesResult{
+					Routes:    []models.Route{{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX"}},
+					Total:     1,
+					Providers: models.ProviderReports{{Name: "provider1", Status: models.ProviderStatusOK}, tt.report},
+				}, nil
+			})
+
+			var errs []*gin.Error
+
+			engine := gin.New()
+			engine.Use(func(c *gin.Context) {
+				c.Next()
+				errs = c.Errors
+			})
+			gen.RegisterHandlers(engine, testHandlers{
+				RouteHandler: NewRouteHandler(routes, testAirports, testAirlines, logger.Context(t.Context())),
+			})
+
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/routes?strict=true", nil))
+
+			if tt.wantCode != "" {
+				require.Len(t, errs, 1)
+				assert.Equal(t, tt.wantCode, apperrors.As(errs[0].Err).Code)
+				assert.NotContains(t, errs[0].Err.Error(), "secret.internal")
+				assert.Zero(t, recorder.Body.Len())
+
+				return
+			}
+
+			assert.Empty(t, errs)
+			assert.Equal(t, http.StatusOK, recorder.Code)
+		})
+	}
+}
+
+func TestRouteHandler_GetRoutes_ReportsProviderErrorReasons(t *testing.T) {
+	t.Parallel()
+
+	routes := routesFunc(func(context.Context, models.RouteFilters) (models.RoutesResult, error) {
+		return models.RoutesResult{Providers: models.ProviderReports{
+			{
+				Name:   "provider1",
+				Status: models.ProviderStatusFailed,
+				Error:  fmt.Errorf("provider1 did not respond in time: %w", context.DeadlineExceeded),
+			},
+			{
+				Name:   "provider2",
+				Status: models.ProviderStatusFailed,
+				Error: apperrors.New(apperrors.UpstreamUnavailable,
+					"provider2 request failed: <html>secret.internal:8443 stack trace</html>"),
+			},
+		}}, nil
+	})
+
+	response := getRoutes(t, routes, "/api/v1/routes")
+
+	require.Len(t, response.Meta.Providers, 2)
+	assert.Equal(t, "timeout", *response.Meta.Providers[0].Error)
+	assert.Equal(t, "upstream error", *response.Meta.Providers[1].Error)
+}
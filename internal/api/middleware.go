@@ -1,10 +1,10 @@
 package api
 
 import (
-	"net/http"
+	"fmt"
 	"time"
 
-	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
 	"flight-booking/internal/services/logger"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
@@ -34,16 +34,8 @@ func Panic() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		defer func() {
 			if err := recover(); err != nil {
-				logger.Context(c.Request.Context()).Error("Panic recovered", "error", err)
-
-				errorResponse := gen.ErrorResponse{
-					Error:     "Internal server error",
-					Code:      http.StatusInternalServerError,
-					Timestamp: time.Now(),
-				}
-
-				c.JSON(http.StatusInternalServerError, errorResponse)
-				c.Abort()
+				// AbortWithProblem logs internal errors.
+				AbortWithProblem(c, apperrors.New(apperrors.Internal, fmt.Sprint("panic recovered: ", err)))
 			}
 		}()
 
@@ -51,29 +43,6 @@ func Panic() gin.HandlerFunc {
 	}
 }
 
-func ErrorHandler() func(*gin.Context, error, int) {
-	return func(c *gin.Context, _ error, _ int) {
-		c.Next()
-
-		if len(c.Errors) > 0 {
-			for _, err := range c.Errors {
-				logger.Context(c.Request.Context()).Error("Error in API handler", "error", err.Err)
-			}
-
-			errorResponse := gen.ErrorResponse{
-				Error:     "Internal server error",
-				Code:      http.StatusInternalServerError,
-				Timestamp: time.Now(),
-			}
-
-			c.JSON(http.StatusInternalServerError, errorResponse)
-			c.Abort()
-
-			return
-		}
-	}
-}
-
 func RequestID() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		requestID := c.GetHeader("X-Request-ID")
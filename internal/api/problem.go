@@ -0,0 +1,103 @@
+package api
+
+import (
+	"net/http"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/services/logger"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	problemContentType = "application/problem+json"
+	problemTypeBase    = "https://api.funwithflights.com/problems/"
+)
+
+type problemKind struct {
+	status int
+	title  string
+}
+
+var problemKinds = map[apperrors.Code]problemKind{
+	apperrors.Validation:          {http.StatusBadRequest, "Invalid request"},
+	apperrors.NotFound:            {http.StatusNotFound, "Not found"},
+	apperrors.Gone:                {http.StatusGone, "Gone"},
+	apperrors.RateLimited:         {http.StatusTooManyRequests, "Too many requests"},
+	apperrors.UpstreamUnavailable: {http.StatusBadGateway, "Upstream unavailable"},
+	apperrors.Internal:            {http.StatusInternalServerError, "Internal server error"},
+}
+
+// AbortWithProblem ends the request with an RFC 7807 response describing err.
+// Errors without an apperrors code are reported as internal errors, and the
+// details of internal errors are only logged.
+func AbortWithProblem(c *gin.Context, err error) {
+	appErr := apperrors.As(err)
+
+	kind, ok := problemKinds[appErr.Code]
+	if !ok {
+		appErr = apperrors.Wrap(apperrors.Internal, err)
+		kind = problemKinds[apperrors.Internal]
+	}
+
+	detail := appErr.Error()
+	if appErr.Code == apperrors.Internal {
+		logger.Context(c.Request.Context()).Error("Error in API handler", "error", err)
+
+		detail = kind.title
+	}
+
+	problem := gen.Problem{
+		Type:     problemTypeBase + string(appErr.Code),
+		Title:    kind.title,
+		Status:   kind.status,
+		Detail:   detail,
+		Instance: c.GetString("request_id"),
+		Code:     gen.ProblemCode(appErr.Code),
+	}
+
+	if len(appErr.Fields) > 0 {
+		fields := make([]gen.FieldError, len(appErr.Fields))
+		for i, field := range appErr.Fields {
+			fields[i] = gen.FieldError{Field: field.Field, Message: field.Message}
+		}
+
+		problem.Errors = &fields
+	}
+
+	c.Header("Content-Type", problemContentType)
+	c.AbortWithStatusJSON(kind.status, problem)
+}
+
+// Errors renders the last error a handler attached with c.Error as a problem
+// response, unless the handler has already written one.
+func Errors() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 || c.Writer.Written() {
+			return
+		}
+
+		AbortWithProblem(c, c.Errors.Last().Err)
+	}
+}
+
+// ErrorHandler reports parameter binding errors of the generated server
+// wrapper, which are always caused by the request.
+func ErrorHandler() func(*gin.Context, error, int) {
+	return func(c *gin.Context, err error, statusCode int) {
+		if statusCode == http.StatusBadRequest {
+			err = apperrors.Wrap(apperrors.Validation, err)
+		}
+
+		AbortWithProblem(c, err)
+	}
+}
+
+// NoRoute reports requests to unknown paths.
+func NoRoute() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		AbortWithProblem(c, apperrors.New(apperrors.NotFound, "no route for "+c.Request.Method+" "+c.Request.URL.Path))
+	}
+}
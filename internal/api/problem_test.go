@@ -0,0 +1,111 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"flight-booking/internal/api/gen"
+	"flight-booking/internal/apperrors"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func serveProblem(t *testing.T, handler gin.HandlerFunc, target string) (*httptest.ResponseRecorder, gen.Problem) {
+	t.Helper()
+
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(RequestID(), Panic(), Errors())
+	engine.NoRoute(NoRoute())
+	engine.GET("/test", handler)
+
+	request := httptest.NewRequest(http.MethodGet, target, nil)
+	request.Header.Set("X-Request-ID", "req-1")
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, request)
+
+	var problem gen.Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, problemContentType, recorder.Header().Get("Content-Type"))
+	assert.Equal(t, recorder.Code, problem.Status)
+	assert.Equal(t, "req-1", problem.Instance)
+
+	return recorder, problem
+}
+
+func TestAbortWithProblem_MapsCodes(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+		code   gen.ProblemCode
+		detail string
+	}{
+		{apperrors.New(apperrors.Validation, "bad input"), http.StatusBadRequest, gen.Validation, "bad input"},
+		{apperrors.New(apperrors.NotFound, "no such airport"), http.StatusNotFound, gen.NotFound, "no such airport"},
+		{apperrors.New(apperrors.RateLimited, "slow down"), http.StatusTooManyRequests, gen.RateLimited, "slow down"},
+		{
+			fmt.Errorf("usecase: %w", apperrors.New(apperrors.UpstreamUnavailable, "provider1 down")),
+			http.StatusBadGateway, gen.UpstreamUnavailable, "provider1 down",
+		},
+		{errors.New("database password is hunter2"), http.StatusInternalServerError, gen.Internal, "Internal server error"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.code), func(t *testing.T) {
+			recorder, problem := serveProblem(t, func(c *gin.Context) { _ = c.Error(tt.err) }, "/test")
+
+			assert.Equal(t, tt.status, recorder.Code)
+			assert.Equal(t, tt.code, problem.Code)
+			assert.Equal(t, tt.detail, problem.Detail)
+			assert.Equal(t, problemTypeBase+string(tt.code), problem.Type)
+			assert.Nil(t, problem.Errors)
+		})
+	}
+}
+
+func TestAbortWithProblem_FieldErrors(t *testing.T) {
+	_, problem := serveProblem(t, func(c *gin.Context) {
+		_ = c.Error(apperrors.New(apperrors.Validation, "invalid", apperrors.FieldError{Field: "limit", Message: "too big"}))
+	}, "/test")
+
+	require.NotNil(t, problem.Errors)
+	assert.Equal(t, []gen.FieldError{{Field: "limit", Message: "too big"}}, *problem.Errors)
+}
+
+func TestPanic_ReportsInternalProblem(t *testing.T) {
+	recorder, problem := serveProblem(t, func(_ *gin.Context) { panic("boom") }, "/test")
+
+	assert.Equal(t, http.StatusInternalServerError, recorder.Code)
+	assert.Equal(t, gen.Internal, problem.Code)
+	assert.NotContains(t, problem.Detail, "boom")
+}
+
+func TestNoRoute_ReportsNotFoundProblem(t *testing.T) {
+	recorder, problem := serveProblem(t, func(c *gin.Context) { c.Status(http.StatusOK) }, "/missing")
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code)
+	assert.Equal(t, gen.NotFound, problem.Code)
+}
+
+func TestErrorHandler_ReportsBindingErrorsAsValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	gen.RegisterHandlersWithOptions(engine, nil, gen.GinServerOptions{ErrorHandler: ErrorHandler()})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/api/v1/routes?limit=ten", nil))
+
+	var problem gen.Problem
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, http.StatusBadRequest, recorder.Code)
+	assert.Equal(t, gen.Validation, problem.Code)
+	assert.Contains(t, problem.Detail, "limit")
+}
@@ -5,7 +5,6 @@ import (
 	"errors"
 	"net"
 	"net/http"
-	"time"
 
 	"flight-booking/internal/api/gen"
 	"flight-booking/internal/api/handlers"
@@ -17,18 +16,36 @@ import (
 
 func NewServer(
 	routeHandlers *handlers.RouteHandler,
+	itineraryHandlers *handlers.ItineraryHandler,
+	airportHandlers *handlers.AirportHandler,
+	airlineHandlers *handlers.AirlineHandler,
+	autocompleteHandlers *handlers.AutocompleteHandler,
 	healthHandlers *handlers.HealthHandler,
 
+	spec Spec,
 	logger logger.Logger,
 	config config.Config,
 	lc fx.Lifecycle,
-) {
+) error {
 	allHandlers := struct {
 		*handlers.RouteHandler
+		*handlers.ItineraryHandler
+		*handlers.AirportHandler
+		*handlers.AirlineHandler
+		*handlers.AutocompleteHandler
 		*handlers.HealthHandler
 	}{
-		RouteHandler:  routeHandlers,
-		HealthHandler: healthHandlers,
+		RouteHandler:        routeHandlers,
+		ItineraryHandler:    itineraryHandlers,
+		AirportHandler:      airportHandlers,
+		AirlineHandler:      airlineHandlers,
+		AutocompleteHandler: autocompleteHandlers,
+		HealthHandler:       healthHandlers,
+	}
+
+	validateRequest, err := ValidateRequest(spec)
+	if err != nil {
+		return err
 	}
 
 	engine := gin.New()
@@ -37,7 +54,10 @@ func NewServer(
 		ContextLogger(logger),
 		RequestLogger(),
 		Panic(),
+		Errors(),
+		validateRequest,
 	)
+	engine.NoRoute(NoRoute())
 
 	gen.RegisterHandlersWithOptions(engine, allHandlers, gen.GinServerOptions{
 		ErrorHandler: ErrorHandler(),
@@ -46,15 +66,17 @@ func NewServer(
 	srv := &http.Server{
 		Addr:              net.JoinHostPort(config.Server.Host, config.Server.Port),
 		Handler:           engine.Handler(),
-		ReadHeaderTimeout: 60 * time.Second,
-		ReadTimeout:       60 * time.Second,
-		WriteTimeout:      60 * time.Second,
+		ReadHeaderTimeout: config.Server.ReadHeaderTimeout,
+		ReadTimeout:       config.Server.ReadTimeout,
+		WriteTimeout:      config.Server.WriteTimeout,
+		IdleTimeout:       config.Server.IdleTimeout,
+		MaxHeaderBytes:    config.Server.MaxHeaderBytes,
 	}
 
 	lc.Append(fx.StartHook(func(_ context.Context) error {
 		go func() {
 			if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
-				logger.Error("listen: %s\n", err)
+				logger.Error("listen failed", "error", err)
 			}
 		}()
 
@@ -62,12 +84,17 @@ func NewServer(
 	}))
 
 	lc.Append(fx.StopHook(func(ctx context.Context) error {
-		logger.Info("shutting down server...")
+		logger.Info("shutting down server...", "grace", config.Server.GracefulShutdownTimeout)
+
+		ctx, cancel := context.WithTimeout(ctx, config.Server.GracefulShutdownTimeout)
+		defer cancel()
 
 		if err := srv.Shutdown(ctx); err != nil {
-			logger.Error("shutdown failed", err)
+			logger.Error("shutdown failed", "error", err)
 		}
 
 		return nil
 	}))
+
+	return nil
 }
@@ -0,0 +1,360 @@
+package api
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"flight-booking/internal/apperrors"
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+)
+
+// Spec is the OpenAPI document the API is generated from.
+type Spec []byte
+
+var (
+	pathParamPattern = regexp.MustCompile(`\{([^}]+)\}`)
+	httpMethods      = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+	// parameterStyles are the serialization styles supported for each
+	// parameter location, the first one being the default.
+	parameterStyles = map[string][]string{
+		"query": {"form"},
+		"path":  {"simple"},
+	}
+	// schemaFormats are the formats supported for each schema type. They do
+	// not change how values are checked.
+	schemaFormats = map[string][]string{
+		"integer": {"int32", "int64"},
+		"number":  {"float", "double"},
+	}
+)
+
+type specDocument struct {
+	Paths map[string]map[string]yaml.Node `yaml:"paths"`
+}
+
+type specOperation struct {
+	Parameters []yaml.Node `yaml:"parameters"`
+}
+
+// specParameter is a parameter declaration. Parameters are decoded strictly,
+// so any keyword without a field here fails startup instead of being skipped.
+type specParameter struct {
+	Ref         string     `yaml:"$ref"`
+	Name        string     `yaml:"name"`
+	In          string     `yaml:"in"`
+	Description string     `yaml:"description"`
+	Required    bool       `yaml:"required"`
+	Style       string     `yaml:"style"`
+	Explode     *bool      `yaml:"explode"`
+	Schema      specSchema `yaml:"schema"`
+}
+
+type specSchema struct {
+	Ref       string      `yaml:"$ref"`
+	Type      string      `yaml:"type"`
+	Format    string      `yaml:"format"`
+	Pattern   string      `yaml:"pattern"`
+	Enum      []string    `yaml:"enum"`
+	Minimum   *float64    `yaml:"minimum"`
+	Maximum   *float64    `yaml:"maximum"`
+	MinLength *int        `yaml:"minLength"`
+	MaxLength *int        `yaml:"maxLength"`
+	Items     *specSchema `yaml:"items"`
+	// Default and Example are documentation only.
+	Default any `yaml:"default"`
+	Example any `yaml:"example"`
+
+	pattern *regexp.Regexp
+}
+
+// ValidateRequest returns a middleware that checks the query and path
+// parameters of every request against the operation declared for its route in
+// spec. Requests with invalid parameters are rejected with a validation problem
+// listing each failing parameter; routes missing from the spec are passed through.
+func ValidateRequest(spec Spec) (gin.HandlerFunc, error) {
+	operations, err := parseOperations(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(c *gin.Context) {
+		params, ok := operations[c.Request.Method+" "+c.FullPath()]
+		if !ok {
+			c.Next()
+
+			return
+		}
+
+		var invalid []apperrors.FieldError
+
+		for _, param := range params {
+			if message := param.validate(c); message != "" {
+				invalid = append(invalid, apperrors.FieldError{Field: param.Name, Message: message})
+			}
+		}
+
+		if len(invalid) > 0 {
+			AbortWithProblem(c, apperrors.New(apperrors.Validation, "Invalid request parameters", invalid...))
+
+			return
+		}
+
+		c.Next()
+	}, nil
+}
+
+// parseOperations indexes the parameters of every operation in spec by HTTP
+// method and gin route path. Parameters using constructs the validator does
+// not support are reported as errors, so that a spec change cannot turn
+// validation off unnoticed.
+func parseOperations(spec Spec) (map[string][]specParameter, error) {
+	var doc specDocument
+	if err := yaml.Unmarshal(spec, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse OpenAPI spec: %w", err)
+	}
+
+	operations := make(map[string][]specParameter)
+
+	for path, item := range doc.Paths {
+		var shared []specParameter
+
+		if node, ok := item["parameters"]; ok {
+			var nodes []yaml.Node
+			if err := node.Decode(&nodes); err != nil {
+				return nil, fmt.Errorf("failed to parse parameters of %s: %w", path, err)
+			}
+
+			params, err := decodeParameters(nodes)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse parameters of %s: %w", path, err)
+			}
+
+			shared = params
+		}
+
+		route := pathParamPattern.ReplaceAllString(path, ":$1")
+
+		for method, node := range item {
+			if method == "parameters" || !slices.Contains(httpMethods, method) {
+				continue
+			}
+
+			var op specOperation
+			if err := node.Decode(&op); err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s: %w", method, path, err)
+			}
+
+			params, err := decodeParameters(op.Parameters)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse %s %s: %w", method, path, err)
+			}
+
+			operations[strings.ToUpper(method)+" "+route] = append(slices.Clone(shared), params...)
+		}
+	}
+
+	return operations, nil
+}
+
+// decodeParameters decodes and checks parameter declarations, rejecting
+// keywords the validator does not know.
+func decodeParameters(nodes []yaml.Node) ([]specParameter, error) {
+	params := make([]specParameter, len(nodes))
+
+	for i := range nodes {
+		data, err := yaml.Marshal(&nodes[i])
+		if err != nil {
+			return nil, err
+		}
+
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+
+		if err := decoder.Decode(&params[i]); err != nil {
+			return nil, fmt.Errorf("parameter %d: %w", i+1, err)
+		}
+
+		if err := params[i].compile(); err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", params[i].Name, err)
+		}
+	}
+
+	return params, nil
+}
+
+func (p *specParameter) compile() error {
+	if p.Ref != "" {
+		return fmt.Errorf("references are not supported, got $ref %s", p.Ref)
+	}
+
+	styles, ok := parameterStyles[p.In]
+	if !ok {
+		return fmt.Errorf("only query and path parameters are supported, got in %q", p.In)
+	}
+
+	if p.Style == "" {
+		p.Style = styles[0]
+	}
+
+	if !slices.Contains(styles, p.Style) {
+		return fmt.Errorf("style %q is not supported for %s parameters", p.Style, p.In)
+	}
+
+	return p.Schema.compile(false)
+}
+
+// compile checks that the schema only uses supported keywords and compiles
+// its pattern. Items of arrays are scalars.
+func (s *specSchema) compile(item bool) error {
+	if s.Ref != "" {
+		return fmt.Errorf("references are not supported, got $ref %s", s.Ref)
+	}
+
+	switch s.Type {
+	case "integer", "number", "boolean", "string":
+	case "array":
+		if item {
+			return errors.New("arrays of arrays are not supported")
+		}
+
+		if s.Items == nil {
+			return errors.New("array schemas must declare items")
+		}
+
+		if err := s.Items.compile(true); err != nil {
+			return fmt.Errorf("items: %w", err)
+		}
+	default:
+		return fmt.Errorf("schema type %q is not supported", s.Type)
+	}
+
+	if s.Format != "" && !slices.Contains(schemaFormats[s.Type], s.Format) {
+		return fmt.Errorf("format %q is not supported for %s schemas", s.Format, s.Type)
+	}
+
+	if s.Pattern != "" {
+		pattern, err := regexp.Compile(s.Pattern)
+		if err != nil {
+			return err
+		}
+
+		s.pattern = pattern
+	}
+
+	return nil
+}
+
+// validate returns why the parameter's value in the request is invalid, or an
+// empty string if it is valid.
+func (p specParameter) validate(c *gin.Context) string {
+	var values []string
+
+	if p.In == "query" {
+		values = c.QueryArray(p.Name)
+	} else if value := c.Param(p.Name); value != "" {
+		values = []string{value}
+	}
+
+	if len(values) == 0 {
+		if p.Required {
+			return "is required"
+		}
+
+		return ""
+	}
+
+	if p.Schema.Type == "array" {
+		if p.Explode != nil && !*p.Explode {
+			if len(values) > 1 {
+				return "must be given at most once"
+			}
+
+			values = strings.Split(values[0], ",")
+		}
+
+		for _, value := range values {
+			if message := p.Schema.Items.validate(value); message != "" {
+				return message
+			}
+		}
+
+		return ""
+	}
+
+	if len(values) > 1 {
+		return "must be given at most once"
+	}
+
+	return p.Schema.validate(values[0])
+}
+
+func (s *specSchema) validate(value string) string {
+	switch s.Type {
+	case "integer":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Sprintf("must be an integer, got %q", value)
+		}
+
+		return s.validateRange(float64(n))
+	case "number":
+		n, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Sprintf("must be a number, got %q", value)
+		}
+
+		return s.validateRange(n)
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Sprintf("must be true or false, got %q", value)
+		}
+	default:
+		return s.validateString(value)
+	}
+
+	return ""
+}
+
+func (s *specSchema) validateRange(n float64) string {
+	if s.Minimum != nil && n < *s.Minimum {
+		return "must be at least " + formatNumber(*s.Minimum)
+	}
+
+	if s.Maximum != nil && n > *s.Maximum {
+		return "must be at most " + formatNumber(*s.Maximum)
+	}
+
+	return ""
+}
+
+func (s *specSchema) validateString(value string) string {
+	length := len([]rune(value))
+
+	if s.MinLength != nil && length < *s.MinLength {
+		return fmt.Sprintf("must be at least %d characters long", *s.MinLength)
+	}
+
+	if s.MaxLength != nil && length > *s.MaxLength {
+		return fmt.Sprintf("must be at most %d characters long", *s.MaxLength)
+	}
+
+	if s.pattern != nil && !s.pattern.MatchString(value) {
+		return fmt.Sprintf("must match pattern %s, got %q", s.Pattern, value)
+	}
+
+	if len(s.Enum) > 0 && !slices.Contains(s.Enum, value) {
+		return fmt.Sprintf("must be one of %s, got %q", strings.Join(s.Enum, ", "), value)
+	}
+
+	return ""
+}
+
+func formatNumber(n float64) string {
+	return strconv.FormatFloat(n, 'f', -1, 64)
+}
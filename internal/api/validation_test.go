@@ -0,0 +1,296 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"flight-booking/internal/api/gen"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newValidatedEngine(t *testing.T) *gin.Engine {
+	t.Helper()
+
+	spec, err := os.ReadFile("../../openapi.yaml")
+	require.NoError(t, err)
+
+	validateRequest, err := ValidateRequest(spec)
+	require.NoError(t, err)
+
+	gin.SetMode(gin.TestMode)
+
+	engine := gin.New()
+	engine.Use(validateRequest)
+	engine.GET("/api/v1/routes", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/api/v1/itineraries", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/api/v1/airlines", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/api/v1/airlines/:code", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/api/v1/airports", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/api/v1/airports/:code", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/api/v1/autocomplete", func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET("/unspecified", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	return engine
+}
+
+func TestValidateRequest(t *testing.T) {
+	engine := newValidatedEngine(t)
+
+	tests := []struct {
+		name    string
+		target  string
+		invalid []gen.FieldError
+	}{
+		{
+			name:   "no parameters",
+			target: "/api/v1/routes",
+		},
+		{
+			name:   "valid parameters",
+			target: "/api/v1/routes?airline=AA,BA&excludeAirline=FR&sourceAirport=JFK,EWR&destinationAirport=LAX&codeShare=N&equipment=737,320&maxStops=0&minDistanceKm=0&maxDistanceKm=5000&nearLat=-33.9&nearLon=151.2&radiusKm=1000&sort=stops,-distanceKm&limit=1000&offset=5&expand=airports&strict=true",
+		},
+		{
+			name:   "valid path parameter",
+			target: "/api/v1/airports/LHR",
+		},
+		{
+			name:   "airline codes may contain digits",
+			target: "/api/v1/airlines/3U",
+		},
+		{
+			name:   "parameters outside the spec are ignored",
+			target: "/api/v1/routes?foo=bar",
+		},
+		{
+			name:   "routes missing from the spec are not validated",
+			target: "/unspecified?limit=100000",
+		},
+		{
+			name:   "limit above maximum",
+			target: "/api/v1/routes?limit=100000",
+			invalid: []gen.FieldError{
+				{Field: "limit", Message: "must be at most 1000"},
+			},
+		},
+		{
+			name:   "every failing parameter is listed",
+			target: "/api/v1/routes?airline=aa&sourceAirport=JFKX&maxStops=-1&minDistanceKm=-5&limit=0&offset=x&strict=maybe",
+			invalid: []gen.FieldError{
+				{Field: "airline", Message: `must match pattern ^[A-Z0-9]{2}$, got "aa"`},
+				{Field: "sourceAirport", Message: `must match pattern ^[A-Z]{3}$, got "JFKX"`},
+				{Field: "maxStops", Message: "must be at least 0"},
+				{Field: "minDistanceKm", Message: "must be at least 0"},
+				{Field: "limit", Message: "must be at least 1"},
+				{Field: "offset", Message: `must be an integer, got "x"`},
+				{Field: "strict", Message: `must be true or false, got "maybe"`},
+			},
+		},
+		{
+			name:   "coordinates out of range",
+			target: "/api/v1/routes?nearLat=91&nearLon=-180.5&radiusKm=0&destinationNearLat=north",
+			invalid: []gen.FieldError{
+				{Field: "nearLat", Message: "must be at most 90"},
+				{Field: "nearLon", Message: "must be at least -180"},
+				{Field: "radiusKm", Message: "must be at least 1"},
+				{Field: "destinationNearLat", Message: `must be a number, got "north"`},
+			},
+		},
+		{
+			name:   "sort by unknown field",
+			target: "/api/v1/routes?sort=stops,-price",
+			invalid: []gen.FieldError{
+				{Field: "sort", Message: `must match pattern ^-?(airline|sourceAirport|destinationAirport|codeShare|stops|equipment|provider|distanceKm)(,-?(airline|sourceAirport|destinationAirport|codeShare|stops|equipment|provider|distanceKm))*$, got "stops,-price"`},
+			},
+		},
+		{
+			name:   "invalid value in a list",
+			target: "/api/v1/routes?airline=AA,ba&equipment=737,",
+			invalid: []gen.FieldError{
+				{Field: "airline", Message: `must match pattern ^[A-Z0-9]{2}$, got "ba"`},
+				{Field: "equipment", Message: `must match pattern ^[A-Z0-9]{3}$, got ""`},
+			},
+		},
+		{
+			name:   "code share outside enum",
+			target: "/api/v1/routes?codeShare=yes",
+			invalid: []gen.FieldError{
+				{Field: "codeShare", Message: `must be one of Y, N, got "yes"`},
+			},
+		},
+		{
+			name:   "missing required parameter",
+			target: "/api/v1/itineraries?sourceAirport=JFK&maxLegs=5",
+			invalid: []gen.FieldError{
+				{Field: "destinationAirport", Message: "is required"},
+				{Field: "maxLegs", Message: "must be at most 4"},
+			},
+		},
+		{
+			name:   "unknown expansion",
+			target: "/api/v1/routes?expand=airports,aircraft",
+			invalid: []gen.FieldError{
+				{Field: "expand", Message: `must be one of airports, airlines, got "aircraft"`},
+			},
+		},
+		{
+			name:   "autocomplete without query",
+			target: "/api/v1/autocomplete?limit=51",
+			invalid: []gen.FieldError{
+				{Field: "q", Message: "is required"},
+				{Field: "limit", Message: "must be at most 50"},
+			},
+		},
+		{
+			name:   "invalid path parameter",
+			target: "/api/v1/airports/lhr",
+			invalid: []gen.FieldError{
+				{Field: "code", Message: `must match pattern ^[A-Z]{3}$, got "lhr"`},
+			},
+		},
+		{
+			name:   "alliance outside enum",
+			target: "/api/v1/airlines?alliance=vanilla&active=yes",
+			invalid: []gen.FieldError{
+				{Field: "active", Message: `must be true or false, got "yes"`},
+				{Field: "alliance", Message: `must be one of oneworld, skyteam, star_alliance, got "vanilla"`},
+			},
+		},
+		{
+			name:   "search text too long",
+			target: "/api/v1/airports?q=xxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxxx",
+			invalid: []gen.FieldError{
+				{Field: "q", Message: "must be at most 64 characters long"},
+			},
+		},
+		{
+			name:   "repeated single value parameter",
+			target: "/api/v1/routes?airline=AA&airline=BA",
+			invalid: []gen.FieldError{
+				{Field: "airline", Message: "must be given at most once"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.target, nil))
+
+			if tt.invalid == nil {
+				assert.Equal(t, http.StatusOK, recorder.Code)
+
+				return
+			}
+
+			require.Equal(t, http.StatusBadRequest, recorder.Code)
+
+			var problem gen.Problem
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+			assert.Equal(t, gen.Validation, problem.Code)
+			require.NotNil(t, problem.Errors)
+			assert.Equal(t, tt.invalid, *problem.Errors)
+		})
+	}
+}
+
+func TestValidateRequest_InvalidSpec(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter string
+		want      string
+	}{
+		{
+			name: "invalid pattern",
+			parameter: `
+        - name: airline
+          in: query
+          schema:
+            type: string
+            pattern: "[A-Z"`,
+			want: "missing closing ]",
+		},
+		{
+			name: "parameter reference",
+			parameter: `
+        - $ref: "#/components/parameters/Airline"`,
+			want: "references are not supported",
+		},
+		{
+			name: "schema reference",
+			parameter: `
+        - name: airline
+          in: query
+          schema:
+            $ref: "#/components/schemas/Airline"`,
+			want: "references are not supported",
+		},
+		{
+			name: "header parameter",
+			parameter: `
+        - name: X-Airline
+          in: header
+          schema:
+            type: string`,
+			want: `only query and path parameters are supported, got in "header"`,
+		},
+		{
+			name: "unknown keyword",
+			parameter: `
+        - name: limit
+          in: query
+          schema:
+            type: integer
+            exclusiveMinimum: 0`,
+			want: "field exclusiveMinimum not found",
+		},
+		{
+			name: "unsupported style",
+			parameter: `
+        - name: airline
+          in: query
+          style: spaceDelimited
+          schema:
+            type: array
+            items:
+              type: string`,
+			want: `style "spaceDelimited" is not supported for query parameters`,
+		},
+		{
+			name: "array without items",
+			parameter: `
+        - name: airline
+          in: query
+          schema:
+            type: array`,
+			want: "array schemas must declare items",
+		},
+		{
+			name: "unsupported format",
+			parameter: `
+        - name: departure
+          in: query
+          schema:
+            type: string
+            format: date-time`,
+			want: `format "date-time" is not supported for string schemas`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateRequest(Spec(`
+paths:
+  /routes:
+    get:
+      parameters:` + tt.parameter + "\n"))
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
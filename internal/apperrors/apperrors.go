@@ -0,0 +1,70 @@
+// Package apperrors defines the errors the service reports to its clients.
+// Every layer can return them; the API maps their Code to an HTTP status.
+package apperrors
+
+import (
+	"errors"
+)
+
+// Code is a stable, machine-readable error category.
+type Code string
+
+const (
+	Validation          Code = "validation"
+	NotFound            Code = "not_found"
+	Gone                Code = "gone"
+	RateLimited         Code = "rate_limited"
+	UpstreamUnavailable Code = "upstream_unavailable"
+	Internal            Code = "internal"
+)
+
+// FieldError describes a single invalid input field.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+// Error is an error with a Code. Its message is meant to be shown to clients,
+// except for Internal errors whose details are only logged.
+type Error struct {
+	Code    Code
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+// New returns an error with the given code and message.
+func New(code Code, message string, fields ...FieldError) *Error {
+	return &Error{Code: code, Message: message, Fields: fields}
+}
+
+// Wrap returns an error with the given code and the message of err.
+func Wrap(code Code, err error) *Error {
+	return &Error{Code: code, Err: err}
+}
+
+func (e *Error) Error() string {
+	switch {
+	case e.Err == nil:
+		return e.Message
+	case e.Message == "":
+		return e.Err.Error()
+	default:
+		return e.Message + ": " + e.Err.Error()
+	}
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// As returns the first *Error in err's chain. Errors without one are reported
+// as Internal.
+func As(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+
+	return Wrap(Internal, err)
+}
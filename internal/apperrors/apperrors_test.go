@@ -0,0 +1,28 @@
+package apperrors
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Message(t *testing.T) {
+	cause := errors.New("connection refused")
+
+	assert.Equal(t, "bad input", New(Validation, "bad input").Error())
+	assert.Equal(t, "connection refused", Wrap(UpstreamUnavailable, cause).Error())
+	assert.Equal(t, "fetch: connection refused", (&Error{Code: Internal, Message: "fetch", Err: cause}).Error())
+	assert.ErrorIs(t, Wrap(UpstreamUnavailable, cause), cause)
+}
+
+func TestAs(t *testing.T) {
+	notFound := New(NotFound, "no such airport")
+
+	assert.Same(t, notFound, As(fmt.Errorf("lookup: %w", notFound)))
+
+	internal := As(errors.New("boom"))
+	assert.Equal(t, Internal, internal.Code)
+	assert.Equal(t, "boom", internal.Error())
+}
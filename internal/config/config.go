@@ -2,41 +2,155 @@ package config
 
 import (
 	"time"
-
-	"github.com/caarlos0/env/v11"
 )
 
-func New() (Config, error) {
-	cfg, err := env.ParseAs[Config]()
+// New loads and validates the configuration. Defaults are applied first, then
+// the YAML file at path (or CONFIG_FILE when path is empty) if one is given,
+// and finally environment variables, which override both. All invalid values
+// are reported at once in a *ValidationError.
+func New(path string) (Config, error) {
+	cfg, src, err := load(path)
 	if err != nil {
 		return Config{}, err
 	}
 
+	if err := validate(cfg, src); err != nil {
+		return Config{}, err
+	}
+
 	return cfg, nil
 }
 
 type Config struct {
-	Server    ServerConfig
-	Log       LogConfig
-	Providers ProvidersConfig
+	// File is the path of the YAML file the config was loaded from, if any.
+	File string `env:"-" yaml:"-"`
+
+	Server     ServerConfig     `yaml:"server"`
+	Log        LogConfig        `yaml:"log"`
+	Cache      CacheConfig      `yaml:"cache"`
+	Aggregator AggregatorConfig `yaml:"aggregator"`
+	Providers  ProvidersConfig  `yaml:"providers"`
+	Warmer     WarmerConfig     `yaml:"warmer"`
+
+	Itineraries ItinerariesConfig `yaml:"itineraries"`
+	Reference   ReferenceConfig   `yaml:"reference"`
 }
 
+// AggregatorConfig controls how routes from all providers are combined.
+type AggregatorConfig struct {
+	// RequestTimeout bounds the whole fan-out to all providers for a single request.
+	RequestTimeout time.Duration `env:"PROVIDERS_REQUEST_TIMEOUT" envDefault:"10s" yaml:"request_timeout"`
+	// SnapshotRetention is how long route data replaced by a provider refresh is
+	// kept for clients paging through it with a cursor.
+	SnapshotRetention time.Duration `env:"ROUTES_SNAPSHOT_RETENTION" envDefault:"10m" yaml:"snapshot_retention"`
+	// SnapshotMaxRetained caps how many replaced snapshots are kept, the oldest
+	// being evicted first, as every one holds a full copy of the route data.
+	SnapshotMaxRetained int `env:"ROUTES_SNAPSHOT_MAX_RETAINED" envDefault:"8" yaml:"snapshot_max_retained"`
+
+	Merge MergeConfig `yaml:"merge"`
+}
+
+// Conflict rules for MergeConfig.
+const (
+	MergeFirst = "first"
+	MergeMin   = "min"
+	MergeMax   = "max"
+	MergeAny   = "any"
+	MergeAll   = "all"
+	MergeUnion = "union"
+)
+
+// MergeConfig controls how routes with the same airline, source and
+// destination reported by several providers are collapsed into one route.
+// Each rule decides the value kept when the providers disagree; "first" keeps
+// the value of the first provider, in declaration order, that reports one.
+type MergeConfig struct {
+	Enabled bool `env:"ROUTES_MERGE_ENABLED" envDefault:"true" yaml:"enabled"`
+	// Stops is one of first, min or max.
+	Stops string `env:"ROUTES_MERGE_STOPS" envDefault:"first" yaml:"stops"`
+	// CodeShare is one of first, any (Y if any provider says Y) or all (Y only if every provider does).
+	CodeShare string `env:"ROUTES_MERGE_CODE_SHARE" envDefault:"first" yaml:"code_share"`
+	// Equipment is one of first or union, which combines the aircraft types of every provider.
+	Equipment string `env:"ROUTES_MERGE_EQUIPMENT" envDefault:"first" yaml:"equipment"`
+}
+
+// ProvidersConfig is declared in YAML as a mapping from provider name to
+// ProviderConfig, in the order the providers are queried.
 type ProvidersConfig struct {
-	Provider1BaseURL  string        `env:"PROVIDER1_BASE_URL"  envDefault:"https://4r5rvu2fcydfzr5gymlhcsnfem0lyxoe.lambda-url.eu-central-1.on.aws/provider/flights1"` //nolint: lll
-	Provider1Timeout  time.Duration `env:"PROVIDER1_TIMEOUT"   envDefault:"30s"`
-	Provider1CacheTTL time.Duration `env:"PROVIDER1_CACHE_TTL" envDefault:"60s"`
+	// Names lists the route providers in the order they are queried.
+	Names []string `env:"PROVIDERS" envDefault:"provider1,provider2"`
+
+	List []ProviderConfig `env:"-"`
+}
+
+// ProviderConfig describes a single upstream route provider. Its environment
+// variables are prefixed with the upper-cased provider name, e.g.
+// PROVIDER1_BASE_URL or PROVIDER1_CACHE_TTL.
+type ProviderConfig struct {
+	Name     string        `env:"-"                         yaml:"-"`
+	BaseURL  string        `env:"BASE_URL"                  yaml:"base_url"`
+	Timeout  time.Duration `env:"TIMEOUT"   envDefault:"30s" yaml:"timeout"`
+	CacheTTL time.Duration `env:"CACHE_TTL" envDefault:"60s" yaml:"cache_ttl"`
+	// CacheMaxStale is how long past CacheTTL cached routes are still served
+	// while they are refreshed in the background or the provider is failing.
+	CacheMaxStale time.Duration `env:"CACHE_MAX_STALE" envDefault:"10m"  yaml:"cache_max_stale"`
+	Retries       int           `env:"RETRIES"         envDefault:"3"    yaml:"retries"`
+	Enabled       bool          `env:"ENABLED"         envDefault:"true" yaml:"enabled"`
+}
+
+// CacheConfig controls the in-memory cache holding provider routes.
+type CacheConfig struct {
+	Enabled bool `env:"CACHE_ENABLED" envDefault:"true" yaml:"enabled"`
+	// DefaultTTL applies to values stored without a TTL of their own.
+	DefaultTTL      time.Duration `env:"CACHE_DEFAULT_TTL"      envDefault:"5m"  yaml:"default_ttl"`
+	CleanupInterval time.Duration `env:"CACHE_CLEANUP_INTERVAL" envDefault:"10m" yaml:"cleanup_interval"`
+	// MaxSize is the maximum number of cached keys; zero means unlimited.
+	MaxSize int `env:"CACHE_MAX_SIZE" envDefault:"10000" yaml:"max_size"`
+}
+
+// WarmerConfig controls prefetching provider routes into the cache.
+type WarmerConfig struct {
+	Enabled bool `env:"WARMER_ENABLED" envDefault:"true" yaml:"enabled"`
+	// BlockReadiness makes the health check fail until every provider has been fetched successfully once.
+	BlockReadiness bool `env:"WARMER_BLOCK_READINESS" envDefault:"false" yaml:"block_readiness"`
+	// RefreshBefore is how long before the cache TTL passes routes are refreshed.
+	RefreshBefore time.Duration `env:"WARMER_REFRESH_BEFORE" envDefault:"10s" yaml:"refresh_before"`
+	// Jitter is the maximum random delay subtracted from each refresh interval.
+	Jitter time.Duration `env:"WARMER_JITTER" envDefault:"5s" yaml:"jitter"`
+}
+
+// ItinerariesConfig controls itinerary search.
+type ItinerariesConfig struct {
+	// MinConnection is the minimum time to change planes between legs of the same airline.
+	MinConnection time.Duration `env:"ITINERARIES_MIN_CONNECTION" envDefault:"45m" yaml:"min_connection"`
+	// MinInterlineConnection is the minimum time to change planes between legs of different airlines.
+	MinInterlineConnection time.Duration `env:"ITINERARIES_MIN_INTERLINE_CONNECTION" envDefault:"90m" yaml:"min_interline_connection"` //nolint: lll
+}
 
-	Provider2BaseURL  string        `env:"PROVIDER2_BASE_URL"  envDefault:"https://4r5rvu2fcydfzr5gymlhcsnfem0lyxoe.lambda-url.eu-central-1.on.aws/provider/flights2"` //nolint: lll
-	Provider2Timeout  time.Duration `env:"PROVIDER2_TIMEOUT"   envDefault:"30s"`
-	Provider2CacheTTL time.Duration `env:"PROVIDER2_CACHE_TTL" envDefault:"60s"`
+// ReferenceConfig locates the reference datasets.
+type ReferenceConfig struct {
+	// AirportsFile is an airport list in the OpenFlights airports.dat format.
+	AirportsFile string `env:"AIRPORTS_FILE" yaml:"airports_file"`
+	// AirlinesFile is an airline list in the OpenFlights airlines.dat format.
+	AirlinesFile string `env:"AIRLINES_FILE" yaml:"airlines_file"`
 }
 
+// ServerConfig controls the HTTP server. Timeouts follow the http.Server fields
+// of the same name.
 type ServerConfig struct {
-	Port string `env:"SERVER_PORT" envDefault:"80"`
-	Host string `env:"SERVER_HOST" envDefault:"0.0.0.0"`
+	Port              string        `env:"SERVER_PORT"                envDefault:"80"      yaml:"port"`
+	Host              string        `env:"SERVER_HOST"                envDefault:"0.0.0.0" yaml:"host"`
+	ReadHeaderTimeout time.Duration `env:"SERVER_READ_HEADER_TIMEOUT" envDefault:"10s"     yaml:"read_header_timeout"`
+	ReadTimeout       time.Duration `env:"SERVER_READ_TIMEOUT"        envDefault:"60s"     yaml:"read_timeout"`
+	WriteTimeout      time.Duration `env:"SERVER_WRITE_TIMEOUT"       envDefault:"60s"     yaml:"write_timeout"`
+	IdleTimeout       time.Duration `env:"SERVER_IDLE_TIMEOUT"        envDefault:"60s"     yaml:"idle_timeout"`
+	MaxHeaderBytes    int           `env:"SERVER_MAX_HEADER_BYTES"    envDefault:"1048576" yaml:"max_header_bytes"`
+	// GracefulShutdownTimeout is how long in-flight requests are given to
+	// finish on shutdown before the service stops anyway.
+	GracefulShutdownTimeout time.Duration `env:"SERVER_GRACEFUL_SHUTDOWN_TIMEOUT" envDefault:"20s" yaml:"graceful_shutdown_timeout"` //nolint: lll
 }
 
 type LogConfig struct {
-	Level  string `env:"LOG_LEVEL"  envDefault:"debug"`
-	Format string `env:"LOG_FORMAT" envDefault:"json"`
+	Level  string `env:"LOG_LEVEL"  envDefault:"debug" yaml:"level"`
+	Format string `env:"LOG_FORMAT" envDefault:"json"  yaml:"format"`
 }
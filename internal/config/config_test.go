@@ -0,0 +1,230 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	return path
+}
+
+func TestNew_Defaults(t *testing.T) {
+	cfg, err := New("")
+
+	require.NoError(t, err)
+	assert.Equal(t, "80", cfg.Server.Port)
+	assert.Equal(t, 10*time.Second, cfg.Server.ReadHeaderTimeout)
+	assert.Equal(t, 1<<20, cfg.Server.MaxHeaderBytes)
+	assert.Equal(t, 10*time.Second, cfg.Aggregator.RequestTimeout)
+	assert.Equal(t, 45*time.Minute, cfg.Itineraries.MinConnection)
+	assert.Equal(t, 90*time.Minute, cfg.Itineraries.MinInterlineConnection)
+	require.Len(t, cfg.Providers.List, 2)
+	assert.Equal(t, "provider1", cfg.Providers.List[0].Name)
+	assert.Equal(t, 60*time.Second, cfg.Providers.List[0].CacheTTL)
+	assert.True(t, cfg.Providers.List[0].Enabled)
+}
+
+func TestNew_RepositoryConfigFile(t *testing.T) {
+	cfg, err := New("../../config.yaml")
+
+	require.NoError(t, err)
+	assert.Equal(t, "8080", cfg.Server.Port)
+	assert.Equal(t, 30*time.Second, cfg.Server.ReadTimeout)
+	assert.Equal(t, 60*time.Second, cfg.Server.IdleTimeout)
+	assert.Equal(t, 30*time.Second, cfg.Server.GracefulShutdownTimeout)
+	assert.Equal(t, "info", cfg.Log.Level)
+	assert.Equal(t, 10000, cfg.Cache.MaxSize)
+	assert.Equal(t, 10*time.Minute, cfg.Cache.CleanupInterval)
+	require.Len(t, cfg.Providers.List, 2)
+	assert.Equal(t, 10*time.Second, cfg.Providers.List[1].Timeout)
+	assert.Equal(t, 3, cfg.Providers.List[1].Retries)
+}
+
+func TestNew_FileWithEnvOverrides(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "8080"
+log:
+  level: "warn"
+providers:
+  provider3:
+    base_url: "https://api.provider3.com"
+    timeout: "5s"
+  provider1:
+    enabled: false
+`)
+
+	t.Setenv("LOG_LEVEL", "error")
+	t.Setenv("PROVIDER3_TIMEOUT", "2s")
+
+	cfg, err := New(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "8080", cfg.Server.Port, "File values should override defaults")
+	assert.Equal(t, "error", cfg.Log.Level, "Environment should override file values")
+	assert.Equal(t, "json", cfg.Log.Format, "Keys missing from the file should keep defaults")
+
+	require.Len(t, cfg.Providers.List, 2)
+	assert.Equal(t, "provider3", cfg.Providers.List[0].Name, "Providers should keep file declaration order")
+	assert.Equal(t, "https://api.provider3.com", cfg.Providers.List[0].BaseURL)
+	assert.Equal(t, 2*time.Second, cfg.Providers.List[0].Timeout)
+	assert.Equal(t, 60*time.Second, cfg.Providers.List[0].CacheTTL)
+	assert.True(t, cfg.Providers.List[0].Enabled)
+	assert.Equal(t, "provider1", cfg.Providers.List[1].Name)
+	assert.False(t, cfg.Providers.List[1].Enabled)
+	assert.NotEmpty(t, cfg.Providers.List[1].BaseURL)
+}
+
+func TestNew_ConfigFileFromEnv(t *testing.T) {
+	t.Setenv("CONFIG_FILE", writeConfigFile(t, "server:\n  port: \"9090\"\n"))
+
+	cfg, err := New("")
+
+	require.NoError(t, err)
+	assert.Equal(t, "9090", cfg.Server.Port)
+}
+
+func TestNew_InvalidFile(t *testing.T) {
+	_, err := New(writeConfigFile(t, "providers: [provider1]\n"))
+	require.Error(t, err)
+
+	_, err = New(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestNew_ValidationReportsEveryProblem(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "http"
+providers:
+  provider1:
+    base_url: ""
+    timeout: "0s"
+`)
+
+	t.Setenv("LOG_LEVEL", "verbose")
+	t.Setenv("PROVIDER1_CACHE_TTL", "0s")
+
+	_, err := New(path)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	assert.ElementsMatch(t, []FieldError{
+		{Key: "server.port", Env: "SERVER_PORT", Source: SourceFile, Message: `must be a port number between 1 and 65535, got "http"`},
+		{Key: "log.level", Env: "LOG_LEVEL", Source: SourceEnv, Message: `must be one of debug, info, warn, error, got "verbose"`},
+		{Key: "providers.provider1.base_url", Env: "PROVIDER1_BASE_URL", Source: SourceFile, Message: `must be an absolute http or https URL, got ""`},
+		{Key: "providers.provider1.timeout", Env: "PROVIDER1_TIMEOUT", Source: SourceFile, Message: "must be a positive duration, got 0s"},
+		{Key: "providers.provider1.cache_ttl", Env: "PROVIDER1_CACHE_TTL", Source: SourceEnv, Message: "must be a positive duration, got 0s"},
+	}, validationErr.Errors)
+
+	assert.Contains(t, err.Error(), "log.level (LOG_LEVEL, from env)")
+}
+
+func TestNew_ValidationReportsUnknownKeys(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "8080"
+  prot: "8080"
+cache:
+  ttl: "5m"
+providers:
+  provider1:
+    base_url: "http://localhost:8081"
+    timout: "5s"
+metrics: true
+`)
+
+	_, err := New(path)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	assert.ElementsMatch(t, []FieldError{
+		{Key: "server.prot", Source: SourceFile, Message: "is not a known setting"},
+		{Key: "cache.ttl", Source: SourceFile, Message: "is not a known setting"},
+		{Key: "providers.provider1.timout", Source: SourceFile, Message: "is not a known setting"},
+		{Key: "metrics", Source: SourceFile, Message: "is not a known setting"},
+	}, validationErr.Errors)
+}
+
+func TestNew_ValidationReportsValuesThatCannotBeDecoded(t *testing.T) {
+	path := writeConfigFile(t, `
+server:
+  port: "abc"
+  read_timeout: "ten"
+log:
+  level: verbose
+cache:
+  max_size: [1]
+providers:
+  provider1:
+    base_url: "http://localhost:8081"
+    retries: "many"
+`)
+
+	t.Setenv("PROVIDER1_TIMEOUT", "xyz")
+	t.Setenv("CACHE_DEFAULT_TTL", "5")
+
+	_, err := New(path)
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	assert.ElementsMatch(t, []FieldError{
+		{Key: "server.port", Env: "SERVER_PORT", Source: SourceFile, Message: `must be a port number between 1 and 65535, got "abc"`},
+		{Key: "server.read_timeout", Env: "SERVER_READ_TIMEOUT", Source: SourceFile, Message: "cannot unmarshal !!str `ten` into time.Duration"},
+		{Key: "log.level", Env: "LOG_LEVEL", Source: SourceFile, Message: `must be one of debug, info, warn, error, got "verbose"`},
+		{Key: "cache.max_size", Env: "CACHE_MAX_SIZE", Source: SourceFile, Message: "cannot unmarshal !!seq into int"},
+		{Key: "cache.default_ttl", Env: "CACHE_DEFAULT_TTL", Source: SourceEnv, Message: `cannot parse "5" into time.Duration`},
+		{Key: "providers.provider1.retries", Env: "PROVIDER1_RETRIES", Source: SourceFile, Message: "cannot unmarshal !!str `many` into int"},
+		{Key: "providers.provider1.timeout", Env: "PROVIDER1_TIMEOUT", Source: SourceEnv, Message: `cannot parse "xyz" into time.Duration`},
+	}, validationErr.Errors)
+}
+
+func TestNew_ValidationReportsUnreadableDatasets(t *testing.T) {
+	dir := t.TempDir()
+	missing := filepath.Join(dir, "airports.dat")
+
+	t.Setenv("AIRPORTS_FILE", missing)
+
+	_, err := New(writeConfigFile(t, "reference:\n  airlines_file: "+strconv.Quote(dir)+"\n"))
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+
+	assert.ElementsMatch(t, []FieldError{
+		{
+			Key: "reference.airports_file", Env: "AIRPORTS_FILE", Source: SourceEnv,
+			Message: "must be a readable file: open " + missing + ": no such file or directory",
+		},
+		{
+			Key: "reference.airlines_file", Env: "AIRLINES_FILE", Source: SourceFile,
+			Message: "must be a readable file: " + dir + " is not a regular file",
+		},
+	}, validationErr.Errors)
+}
+
+func TestNew_ValidationRejectsDefaultsMadeInvalid(t *testing.T) {
+	t.Setenv("PROVIDERS", "provider1,provider3")
+
+	_, err := New("")
+
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.Len(t, validationErr.Errors, 1)
+	assert.Equal(t, "providers.provider3.base_url", validationErr.Errors[0].Key)
+	assert.Equal(t, SourceDefault, validationErr.Errors[0].Source)
+}
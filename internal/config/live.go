@@ -0,0 +1,48 @@
+package config
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// Live holds the current configuration and notifies subscribers when a new
+// one is applied at runtime.
+type Live struct {
+	current atomic.Pointer[Config]
+
+	mu          sync.Mutex
+	subscribers []func(Config)
+}
+
+func NewLive(config Config) *Live {
+	l := &Live{}
+	l.current.Store(&config)
+
+	return l
+}
+
+// Get returns the configuration currently in effect.
+func (l *Live) Get() Config {
+	return *l.current.Load()
+}
+
+// Subscribe registers fn to be called with every configuration applied
+// after this call, in registration order.
+func (l *Live) Subscribe(fn func(Config)) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.subscribers = append(l.subscribers, fn)
+}
+
+// Set applies config and notifies the subscribers.
+func (l *Live) Set(config Config) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.current.Store(&config)
+
+	for _, fn := range l.subscribers {
+		fn(config)
+	}
+}
@@ -0,0 +1,348 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/caarlos0/env/v11"
+	"gopkg.in/yaml.v3"
+)
+
+// overrideOnlyTag is a struct tag no field declares. Parsing environment
+// variables with it as the default value tag leaves fields whose variable is
+// unset untouched instead of resetting them to their envDefault.
+const overrideOnlyTag = "envOverrideOnly"
+
+// unknownFieldPattern matches the errors yaml.v3 reports for keys without a
+// field when decoding with KnownFields.
+var unknownFieldPattern = regexp.MustCompile(`^line (\d+): field (.+) not found in type `)
+
+// decodeErrorPattern matches the other errors yaml.v3 reports for values it
+// cannot decode, such as a string given for a duration.
+var decodeErrorPattern = regexp.MustCompile(`^line (\d+): (.+)$`)
+
+func load(path string) (Config, sources, error) {
+	src := sources{}
+
+	cfg, err := env.ParseAsWithOptions[Config](env.Options{
+		Environment: map[string]string{},
+	})
+	if err != nil {
+		return Config{}, src, err
+	}
+
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+
+	if path != "" {
+		if err := loadFile(path, &cfg, &src); err != nil {
+			return Config{}, src, err
+		}
+
+		cfg.File = path
+	}
+
+	src.invalid = append(src.invalid, applyEnv(&cfg, "", "", envKeys(Config{}))...)
+
+	var invalid []invalidValue
+
+	cfg.Providers.List, invalid, err = resolveProviders(cfg.Providers)
+	if err != nil {
+		return Config{}, src, err
+	}
+
+	src.invalid = append(src.invalid, invalid...)
+
+	return cfg, src, nil
+}
+
+// loadFile decodes the YAML file at path into cfg. It records in src the paths
+// of the keys the file sets, with their line, the paths of the keys that do
+// not match any setting and the values that cannot be decoded.
+func loadFile(path string, cfg *Config, src *sources) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return fmt.Errorf("failed to parse config file %s: %w", path, err)
+	}
+
+	src.file = make(map[string]int)
+	collectKeys(&root, "", src.file)
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+
+	if err := decoder.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		if err := decodeErrors(err, src); err != nil {
+			return fmt.Errorf("failed to parse config file %s: %w", path, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeErrors records the keys and values err reports as not matching any
+// field or not decodable in src. The decoder keeps going past such errors, so
+// every other value of the file is still applied. It returns err itself if it
+// reports anything else.
+func decodeErrors(err error, src *sources) error {
+	var typeErr *yaml.TypeError
+	if !errors.As(err, &typeErr) {
+		return err
+	}
+
+	for _, message := range typeErr.Errors {
+		if match := unknownFieldPattern.FindStringSubmatch(message); match != nil {
+			line, _ := strconv.Atoi(match[1])
+			field := match[2]
+
+			// The error only names the field, so its path is found by line.
+			key := field
+			for path, keyLine := range src.file {
+				if keyLine == line && (path == field || strings.HasSuffix(path, "."+field)) {
+					key = path
+
+					break
+				}
+			}
+
+			src.unknown = append(src.unknown, key)
+
+			continue
+		}
+
+		match := decodeErrorPattern.FindStringSubmatch(message)
+		if match == nil {
+			return err
+		}
+
+		line, _ := strconv.Atoi(match[1])
+
+		key, ok := keyAt(src.file, line)
+		if !ok {
+			return err
+		}
+
+		src.invalid = append(src.invalid, invalidValue{key: key, source: SourceFile, message: match[2]})
+	}
+
+	return nil
+}
+
+// keyAt returns the path of the key a value reported at line belongs to: the
+// last key declared up to that line, the deepest one if several share it.
+func keyAt(keys map[string]int, line int) (string, bool) {
+	key, keyLine := "", 0
+
+	for path, pathLine := range keys {
+		if pathLine > line || pathLine < keyLine {
+			continue
+		}
+
+		if pathLine > keyLine || len(path) > len(key) || (len(path) == len(key) && path < key) {
+			key, keyLine = path, pathLine
+		}
+	}
+
+	return key, key != ""
+}
+
+// applyEnv overrides the fields of target, a pointer to a struct, with the
+// environment variables named by vars, which maps YAML paths under keyPrefix
+// to variable names without prefix. Every variable is applied on its own, so
+// that each value failing to parse is reported with its key and none of the
+// others are skipped.
+func applyEnv(target any, keyPrefix, prefix string, vars map[string]string) []invalidValue {
+	var invalid []invalidValue
+
+	for _, key := range slices.Sorted(maps.Keys(vars)) {
+		name := prefix + vars[key]
+
+		value, ok := os.LookupEnv(name)
+		if !ok {
+			continue
+		}
+
+		err := env.ParseWithOptions(target, env.Options{
+			Environment:         map[string]string{name: value},
+			Prefix:              prefix,
+			DefaultValueTagName: overrideOnlyTag,
+		})
+		if err == nil {
+			continue
+		}
+
+		message := err.Error()
+
+		var parseErr env.ParseError
+		if errors.As(err, &parseErr) {
+			message = fmt.Sprintf("cannot parse %q into %s", value, parseErr.Type)
+		}
+
+		invalid = append(invalid, invalidValue{key: keyPrefix + key, source: SourceEnv, message: message})
+	}
+
+	return invalid
+}
+
+func collectKeys(node *yaml.Node, prefix string, keys map[string]int) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			collectKeys(child, prefix, keys)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i].Value
+			if prefix != "" {
+				key = prefix + "." + key
+			}
+
+			keys[key] = node.Content[i].Line
+			collectKeys(node.Content[i+1], key, keys)
+		}
+	case yaml.SequenceNode, yaml.ScalarNode, yaml.AliasNode:
+	}
+}
+
+// UnmarshalYAML decodes the providers mapping, keeping declaration order.
+// Keys missing from a provider fall back to their defaults. Node.Decode does
+// not reject unknown keys, so they are reported here the way the decoder does
+// with KnownFields; like the decoder, values that cannot be decoded are
+// reported in a *yaml.TypeError once every provider has been decoded.
+func (p *ProvidersConfig) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return &yaml.TypeError{Errors: []string{
+			fmt.Sprintf("line %d: providers must be a mapping of provider name to provider config", node.Line),
+		}}
+	}
+
+	p.Names = make([]string, 0, len(node.Content)/2)
+	p.List = make([]ProviderConfig, 0, len(node.Content)/2)
+
+	known := yamlFields(reflect.TypeFor[ProviderConfig]())
+	typeErr := &yaml.TypeError{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		name := node.Content[i].Value
+
+		provider, err := defaultProviderConfig(name)
+		if err != nil {
+			return err
+		}
+
+		if err := node.Content[i+1].Decode(&provider); err != nil {
+			var decodeErr *yaml.TypeError
+			if !errors.As(err, &decodeErr) {
+				return fmt.Errorf("provider %s: %w", name, err)
+			}
+
+			typeErr.Errors = append(typeErr.Errors, decodeErr.Errors...)
+		}
+
+		fields := node.Content[i+1].Content
+		for j := 0; j+1 < len(fields); j += 2 {
+			if !known[fields[j].Value] {
+				typeErr.Errors = append(typeErr.Errors, fmt.Sprintf("line %d: field %s not found in type %T",
+					fields[j].Line, fields[j].Value, provider))
+			}
+		}
+
+		p.Names = append(p.Names, name)
+		p.List = append(p.List, provider)
+	}
+
+	if len(typeErr.Errors) > 0 {
+		return typeErr
+	}
+
+	return nil
+}
+
+// yamlFields returns the YAML keys of the fields of struct type t.
+func yamlFields(t reflect.Type) map[string]bool {
+	fields := make(map[string]bool, t.NumField())
+
+	for i := range t.NumField() {
+		if name := t.Field(i).Tag.Get("yaml"); name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+
+	return fields
+}
+
+func defaultProviderBaseURLs() map[string]string {
+	return map[string]string{
+		"provider1": "https://4r5rvu2fcydfzr5gymlhcsnfem0lyxoe.lambda-url.eu-central-1.on.aws/provider/flights1",
+		"provider2": "https://4r5rvu2fcydfzr5gymlhcsnfem0lyxoe.lambda-url.eu-central-1.on.aws/provider/flights2",
+	}
+}
+
+func defaultProviderConfig(name string) (ProviderConfig, error) {
+	provider := ProviderConfig{
+		Name:    name,
+		BaseURL: defaultProviderBaseURLs()[name],
+	}
+
+	err := env.ParseWithOptions(&provider, env.Options{
+		Environment: map[string]string{},
+	})
+	if err != nil {
+		return ProviderConfig{}, fmt.Errorf("provider %s: %w", name, err)
+	}
+
+	return provider, nil
+}
+
+// resolveProviders builds the final provider list in the order of the
+// provider names, applying environment overrides on top of the providers
+// declared in the config file or their defaults. It also returns the
+// environment values that cannot be parsed.
+func resolveProviders(cfg ProvidersConfig) ([]ProviderConfig, []invalidValue, error) {
+	declared := make(map[string]ProviderConfig, len(cfg.List))
+	for _, provider := range cfg.List {
+		declared[provider.Name] = provider
+	}
+
+	providers := make([]ProviderConfig, 0, len(cfg.Names))
+	vars := fieldEnvKeys(reflect.TypeFor[ProviderConfig]())
+
+	var invalid []invalidValue
+
+	for _, name := range cfg.Names {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+
+		provider, ok := declared[name]
+		if !ok {
+			var err error
+
+			provider, err = defaultProviderConfig(name)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		invalid = append(invalid, applyEnv(&provider, "providers."+name+".", strings.ToUpper(name)+"_", vars)...)
+		providers = append(providers, provider)
+	}
+
+	return providers, invalid, nil
+}
@@ -0,0 +1,109 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Change describes a configuration value that differs between two configs.
+type Change struct {
+	Key string
+	Old string
+	New string
+}
+
+// Reloadable returns current with every setting that can change at runtime
+// taken from next: providers, the aggregator, the log level, the warmer
+// schedule and itinerary connection times. Everything else, such as the
+// listen address, keeps its current value until the service is restarted.
+func Reloadable(current, next Config) Config {
+	result := current
+	result.Log.Level = next.Log.Level
+	result.Aggregator = next.Aggregator
+	result.Providers = next.Providers
+	result.Warmer.RefreshBefore = next.Warmer.RefreshBefore
+	result.Warmer.Jitter = next.Warmer.Jitter
+	result.Itineraries = next.Itineraries
+
+	return result
+}
+
+// Diff lists the values that differ between old and next, keyed by YAML path.
+func Diff(old, next Config) []Change {
+	oldValues := flatten(old)
+	newValues := flatten(next)
+
+	keys := make([]string, 0, len(oldValues)+len(newValues))
+	for key := range oldValues {
+		keys = append(keys, key)
+	}
+
+	for key := range newValues {
+		if _, ok := oldValues[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+
+	slices.Sort(keys)
+
+	var changes []Change
+
+	for _, key := range keys {
+		if oldValues[key] != newValues[key] {
+			changes = append(changes, Change{Key: key, Old: oldValues[key], New: newValues[key]})
+		}
+	}
+
+	return changes
+}
+
+func flatten(cfg Config) map[string]string {
+	values := map[string]string{
+		"providers": strings.Join(providerNames(cfg.Providers.List), ","),
+	}
+
+	configValue := reflect.ValueOf(cfg)
+
+	for i := range configValue.NumField() {
+		section := configValue.Type().Field(i)
+		if section.Type.Kind() != reflect.Struct || section.Type == reflect.TypeOf(ProvidersConfig{}) {
+			continue
+		}
+
+		flattenStruct(configValue.Field(i), section.Tag.Get("yaml")+".", values)
+	}
+
+	for _, provider := range cfg.Providers.List {
+		flattenStruct(reflect.ValueOf(provider), "providers."+provider.Name+".", values)
+	}
+
+	return values
+}
+
+func flattenStruct(v reflect.Value, prefix string, values map[string]string) {
+	for i := range v.NumField() {
+		name := v.Type().Field(i).Tag.Get("yaml")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if v.Field(i).Kind() == reflect.Struct {
+			flattenStruct(v.Field(i), prefix+name+".", values)
+
+			continue
+		}
+
+		values[prefix+name] = fmt.Sprint(v.Field(i).Interface())
+	}
+}
+
+func providerNames(providers []ProviderConfig) []string {
+	names := make([]string, len(providers))
+	for i, provider := range providers {
+		names[i] = provider.Name
+	}
+
+	return names
+}
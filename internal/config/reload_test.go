@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloadable_KeepsRestartOnlySettings(t *testing.T) {
+	current, err := New("")
+	require.NoError(t, err)
+
+	next := current
+	next.Server.Port = "9090"
+	next.Log.Level = "error"
+	next.Aggregator.RequestTimeout = 3 * time.Second
+	next.Providers.List = current.Providers.List[:1]
+	next.Itineraries.MinConnection = time.Hour
+
+	applied := Reloadable(current, next)
+
+	assert.Equal(t, "80", applied.Server.Port)
+	assert.Equal(t, "error", applied.Log.Level)
+	assert.Equal(t, 3*time.Second, applied.Aggregator.RequestTimeout)
+	assert.Len(t, applied.Providers.List, 1)
+	assert.Equal(t, time.Hour, applied.Itineraries.MinConnection)
+
+	assert.Equal(t, []Change{{Key: "server.port", Old: "80", New: "9090"}}, Diff(applied, next))
+}
+
+func TestDiff(t *testing.T) {
+	old, err := New("")
+	require.NoError(t, err)
+
+	next := old
+	next.Log.Level = "info"
+	next.Providers.List = append([]ProviderConfig{}, old.Providers.List...)
+	next.Providers.List[1].Enabled = false
+	next.Providers.List = append(next.Providers.List, ProviderConfig{
+		Name: "provider3", BaseURL: "https://example.com", Timeout: time.Second,
+	})
+
+	changes := Diff(old, next)
+
+	assert.Contains(t, changes, Change{Key: "log.level", Old: "debug", New: "info"})
+	assert.Contains(t, changes, Change{Key: "providers.provider2.enabled", Old: "true", New: "false"})
+	assert.Contains(t, changes, Change{Key: "providers.provider3.base_url", Old: "", New: "https://example.com"})
+	assert.Contains(t, changes, Change{
+		Key: "providers", Old: "provider1,provider2", New: "provider1,provider2,provider3",
+	})
+	assert.Empty(t, Diff(old, old))
+}
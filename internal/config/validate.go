@@ -0,0 +1,286 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Source string
+
+const (
+	SourceDefault Source = "default"
+	SourceFile    Source = "file"
+	SourceEnv     Source = "env"
+)
+
+// FieldError describes a single invalid configuration value.
+type FieldError struct {
+	// Key is the YAML path of the value, e.g. providers.provider1.base_url.
+	Key string
+	// Env is the environment variable that sets the value.
+	Env     string
+	Source  Source
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s (%s, from %s): %s", e.Key, e.Env, e.Source, e.Message)
+}
+
+// ValidationError lists every invalid value found in the configuration.
+type ValidationError struct {
+	Errors []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	lines := make([]string, len(e.Errors))
+	for i, err := range e.Errors {
+		lines[i] = "  - " + err.Error()
+	}
+
+	return "invalid configuration:\n" + strings.Join(lines, "\n")
+}
+
+// sources records where configuration values were set.
+type sources struct {
+	// file holds the YAML paths present in the config file, with their line.
+	file map[string]int
+	// unknown holds the YAML paths of the config file that match no setting.
+	unknown []string
+	// invalid holds the values of the file and the environment that cannot be
+	// decoded into their setting.
+	invalid []invalidValue
+}
+
+// invalidValue is a configuration value that cannot be decoded, such as a
+// duration without a unit.
+type invalidValue struct {
+	key     string
+	source  Source
+	message string
+}
+
+func (s sources) of(key, env string) Source {
+	if _, ok := os.LookupEnv(env); ok && env != "" {
+		return SourceEnv
+	}
+
+	if _, ok := s.file[key]; ok {
+		return SourceFile
+	}
+
+	return SourceDefault
+}
+
+type validator struct {
+	sources sources
+	envKeys map[string]string
+	errors  []FieldError
+}
+
+func validate(cfg Config, src sources) error {
+	v := &validator{
+		sources: src,
+		envKeys: envKeys(cfg),
+	}
+
+	for _, key := range src.unknown {
+		v.check(false, key, "is not a known setting")
+	}
+
+	for _, value := range src.invalid {
+		v.report(value.key, value.source, value.message)
+	}
+
+	v.port("server.port", cfg.Server.Port)
+	v.positive("server.read_header_timeout", cfg.Server.ReadHeaderTimeout)
+	v.positive("server.read_timeout", cfg.Server.ReadTimeout)
+	v.positive("server.write_timeout", cfg.Server.WriteTimeout)
+	v.positive("server.idle_timeout", cfg.Server.IdleTimeout)
+	v.check(cfg.Server.MaxHeaderBytes > 0, "server.max_header_bytes",
+		"must be a positive number of bytes, got %d", cfg.Server.MaxHeaderBytes)
+	v.positive("server.graceful_shutdown_timeout", cfg.Server.GracefulShutdownTimeout)
+
+	v.oneOf("log.level", cfg.Log.Level, "debug", "info", "warn", "error")
+	v.oneOf("log.format", cfg.Log.Format, "json", "console")
+
+	v.positive("cache.default_ttl", cfg.Cache.DefaultTTL)
+	v.positive("cache.cleanup_interval", cfg.Cache.CleanupInterval)
+	v.check(cfg.Cache.MaxSize >= 0, "cache.max_size", "must not be negative, got %d", cfg.Cache.MaxSize)
+
+	v.positive("aggregator.request_timeout", cfg.Aggregator.RequestTimeout)
+	v.nonNegative("aggregator.snapshot_retention", cfg.Aggregator.SnapshotRetention)
+	v.check(cfg.Aggregator.SnapshotMaxRetained >= 0, "aggregator.snapshot_max_retained",
+		"must not be negative, got %d", cfg.Aggregator.SnapshotMaxRetained)
+	v.oneOf("aggregator.merge.stops", cfg.Aggregator.Merge.Stops, MergeFirst, MergeMin, MergeMax)
+	v.oneOf("aggregator.merge.code_share", cfg.Aggregator.Merge.CodeShare, MergeFirst, MergeAny, MergeAll)
+	v.oneOf("aggregator.merge.equipment", cfg.Aggregator.Merge.Equipment, MergeFirst, MergeUnion)
+
+	v.nonNegative("warmer.refresh_before", cfg.Warmer.RefreshBefore)
+	v.nonNegative("warmer.jitter", cfg.Warmer.Jitter)
+
+	v.nonNegative("itineraries.min_connection", cfg.Itineraries.MinConnection)
+	v.nonNegative("itineraries.min_interline_connection", cfg.Itineraries.MinInterlineConnection)
+
+	v.file("reference.airports_file", cfg.Reference.AirportsFile)
+	v.file("reference.airlines_file", cfg.Reference.AirlinesFile)
+
+	v.providers(cfg.Providers.List)
+
+	if len(v.errors) > 0 {
+		return &ValidationError{Errors: v.errors}
+	}
+
+	return nil
+}
+
+func (v *validator) providers(providers []ProviderConfig) {
+	v.check(len(providers) > 0, "providers", "at least one provider must be declared")
+
+	seen := make(map[string]bool, len(providers))
+
+	for _, provider := range providers {
+		prefix := "providers." + provider.Name + "."
+
+		v.check(!seen[provider.Name], "providers", "provider %q is declared more than once", provider.Name)
+		seen[provider.Name] = true
+
+		v.baseURL(prefix+"base_url", provider.BaseURL)
+		v.positive(prefix+"timeout", provider.Timeout)
+		v.positive(prefix+"cache_ttl", provider.CacheTTL)
+		v.nonNegative(prefix+"cache_max_stale", provider.CacheMaxStale)
+		v.check(provider.Retries >= 0, prefix+"retries", "must not be negative, got %d", provider.Retries)
+	}
+}
+
+func (v *validator) check(ok bool, key, format string, args ...any) {
+	if ok {
+		return
+	}
+
+	v.report(key, v.sources.of(key, v.envKeys[key]), fmt.Sprintf(format, args...))
+}
+
+func (v *validator) report(key string, source Source, message string) {
+	v.errors = append(v.errors, FieldError{
+		Key:     key,
+		Env:     v.envKeys[key],
+		Source:  source,
+		Message: message,
+	})
+}
+
+func (v *validator) positive(key string, d time.Duration) {
+	v.check(d > 0, key, "must be a positive duration, got %s", d)
+}
+
+func (v *validator) nonNegative(key string, d time.Duration) {
+	v.check(d >= 0, key, "must not be a negative duration, got %s", d)
+}
+
+func (v *validator) oneOf(key, value string, allowed ...string) {
+	v.check(slices.Contains(allowed, value), key, "must be one of %s, got %q", strings.Join(allowed, ", "), value)
+}
+
+func (v *validator) port(key, value string) {
+	port, err := strconv.Atoi(value)
+	v.check(err == nil && port > 0 && port <= 65535, key, "must be a port number between 1 and 65535, got %q", value)
+}
+
+// file checks that path, if set, is a readable regular file.
+func (v *validator) file(key, path string) {
+	if path == "" {
+		return
+	}
+
+	err := readable(path)
+	v.check(err == nil, key, "must be a readable file: %v", err)
+}
+
+func readable(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("%s is not a regular file", path)
+	}
+
+	return nil
+}
+
+func (v *validator) baseURL(key, value string) {
+	u, err := url.Parse(value)
+	ok := err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	v.check(ok, key, "must be an absolute http or https URL, got %q", value)
+}
+
+// envKeys maps the YAML path of every config value to the environment
+// variable that sets it, based on the yaml and env struct tags.
+func envKeys(cfg Config) map[string]string {
+	keys := map[string]string{
+		"providers": "PROVIDERS",
+	}
+
+	configType := reflect.TypeOf(cfg)
+
+	for i := range configType.NumField() {
+		section := configType.Field(i)
+		if section.Type.Kind() != reflect.Struct {
+			continue
+		}
+
+		for name, env := range fieldEnvKeys(section.Type) {
+			keys[section.Tag.Get("yaml")+"."+name] = env
+		}
+	}
+
+	for _, provider := range cfg.Providers.List {
+		prefix := strings.ToUpper(provider.Name) + "_"
+
+		for name, env := range fieldEnvKeys(reflect.TypeOf(provider)) {
+			keys["providers."+provider.Name+"."+name] = prefix + env
+		}
+	}
+
+	return keys
+}
+
+func fieldEnvKeys(t reflect.Type) map[string]string {
+	keys := make(map[string]string, t.NumField())
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name := field.Tag.Get("yaml")
+		env := field.Tag.Get("env")
+
+		if field.Type.Kind() == reflect.Struct && name != "" && name != "-" {
+			for nested, env := range fieldEnvKeys(field.Type) {
+				keys[name+"."+nested] = env
+			}
+
+			continue
+		}
+
+		if name == "" || name == "-" || env == "" || env == "-" {
+			continue
+		}
+
+		keys[name] = env
+	}
+
+	return keys
+}
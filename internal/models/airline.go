@@ -0,0 +1,19 @@
+package models
+
+// Airline describes an airline of the reference catalog.
+type Airline struct {
+	IATA     string
+	ICAO     string
+	Name     string
+	Callsign string
+	Country  string
+	// Active is false for airlines that ceased operations.
+	Active bool
+	// Alliance is the alliance the airline is a member of, if any.
+	Alliance string
+}
+
+type AirlineFilters struct {
+	Active   *bool
+	Alliance string
+}
@@ -0,0 +1,18 @@
+package models
+
+// DefaultAirportLimit is the number of airports returned when a search does
+// not set a limit.
+const DefaultAirportLimit = 20
+
+// Airport describes an airport of the reference catalog.
+type Airport struct {
+	IATA      string
+	ICAO      string
+	Name      string
+	City      string
+	Country   string
+	Latitude  float64
+	Longitude float64
+	// Timezone is the tz database name, e.g. Europe/London.
+	Timezone string
+}
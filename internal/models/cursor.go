@@ -0,0 +1,91 @@
+package models
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"hash/fnv"
+	"strconv"
+)
+
+// Cursor points at a position within the routes of one data snapshot. It is
+// only valid for the filters it was issued for.
+type Cursor struct {
+	Snapshot int64  `json:"s"`
+	Position int    `json:"p"`
+	Filters  uint64 `json:"f"`
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// ParseCursor decodes a cursor produced by Cursor.Encode.
+func ParseCursor(s string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return Cursor{}, errInvalidCursor
+	}
+
+	var cursor Cursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.Snapshot <= 0 || cursor.Position < 0 {
+		return Cursor{}, errInvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// Encode returns the cursor as an opaque, URL safe string.
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c) //nolint:errchkjson // a struct of numbers always marshals
+
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// Fingerprint identifies the filters that select and order routes, ignoring
+// paging.
+func (f RouteFilters) Fingerprint() uint64 {
+	optional := func(value *int) string {
+		if value == nil {
+			return "-"
+		}
+
+		return strconv.Itoa(*value)
+	}
+
+	sortKeys := make([]string, len(f.Sort))
+	for i, key := range f.Sort {
+		sortKeys[i] = key.String()
+	}
+
+	// Unset airport filters differ from empty ones, which match no route.
+	airports := func(codes []string) []string {
+		if codes == nil {
+			return []string{"-"}
+		}
+
+		return codes
+	}
+
+	fields := [][]string{
+		f.Airlines,
+		f.ExcludeAirlines,
+		airports(f.SourceAirports),
+		airports(f.DestinationAirports),
+		{f.CodeShare},
+		f.Equipment,
+		{optional(f.MaxStops)},
+		{optional(f.MinDistanceKm), optional(f.MaxDistanceKm)},
+		sortKeys,
+	}
+
+	hash := fnv.New64a()
+	for _, values := range fields {
+		for _, value := range values {
+			_, _ = hash.Write([]byte(value))
+			_, _ = hash.Write([]byte{0})
+		}
+
+		_, _ = hash.Write([]byte{1})
+	}
+
+	return hash.Sum64()
+}
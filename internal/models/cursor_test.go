@@ -0,0 +1,42 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCursor_RoundTrip(t *testing.T) {
+	cursor := Cursor{Snapshot: 1700000000000000000, Position: 150, Filters: RouteFilters{Airlines: []string{"AA"}}.Fingerprint()}
+
+	parsed, err := ParseCursor(cursor.Encode())
+
+	require.NoError(t, err)
+	assert.Equal(t, cursor, parsed)
+}
+
+func TestParseCursor_Invalid(t *testing.T) {
+	for _, value := range []string{"", "not base64!", "bm90IGpzb24", Cursor{Position: 1}.Encode(), Cursor{Snapshot: 1, Position: -1}.Encode()} {
+		_, err := ParseCursor(value)
+		assert.Error(t, err, value)
+	}
+}
+
+func TestRouteFilters_Fingerprint(t *testing.T) {
+	zero, one := 0, 1
+
+	base := RouteFilters{Airlines: []string{"AA"}, SourceAirports: []string{"JFK"}}
+
+	assert.Equal(t, base.Fingerprint(), RouteFilters{Airlines: []string{"AA"}, SourceAirports: []string{"JFK"}, Limit: 5, Offset: 10}.Fingerprint(),
+		"Paging should not change the fingerprint")
+	assert.NotEqual(t, base.Fingerprint(), RouteFilters{Airlines: []string{"AAJ"}, DestinationAirports: []string{"FK"}}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{MaxStops: &zero}.Fingerprint(), RouteFilters{MaxStops: &one}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{}.Fingerprint(), RouteFilters{MaxStops: &zero}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{Airlines: []string{"AA", "BA"}}.Fingerprint(), RouteFilters{Airlines: []string{"AA,BA"}}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{Airlines: []string{"AA"}}.Fingerprint(), RouteFilters{ExcludeAirlines: []string{"AA"}}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{}.Fingerprint(), RouteFilters{CodeShare: "N"}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{}.Fingerprint(), RouteFilters{SourceAirports: []string{}}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{}.Fingerprint(), RouteFilters{Equipment: []string{"737"}}.Fingerprint())
+	assert.NotEqual(t, RouteFilters{MinDistanceKm: &one}.Fingerprint(), RouteFilters{MaxDistanceKm: &one}.Fingerprint())
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+const (
+	// EarthRadiusKm is the mean radius of the earth.
+	EarthRadiusKm = 6371.0
+	// KmPerDegree is the length of a degree of latitude.
+	KmPerDegree = 2 * math.Pi * EarthRadiusKm / 360
+)
+
+const (
+	// cruiseSpeedKmh is the average ground speed of a jet in cruise.
+	cruiseSpeedKmh = 800.0
+	// legOverhead covers taxiing, climb and descent on every leg.
+	legOverhead = 30 * time.Minute
+	// stopTime is the time spent on the ground at every stop.
+	stopTime = 45 * time.Minute
+)
+
+// GreatCircleDistanceKm returns the shortest distance between two airports
+// over the earth's surface.
+func GreatCircleDistanceKm(from, to Airport) float64 {
+	lat1, lat2 := radians(from.Latitude), radians(to.Latitude)
+	dLat := lat2 - lat1
+	dLon := radians(to.Longitude - from.Longitude)
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * EarthRadiusKm * math.Asin(math.Sqrt(min(h, 1)))
+}
+
+// EstimateBlockTime estimates the gate to gate time of a flight over
+// distanceKm making stops intermediate stops.
+func EstimateBlockTime(distanceKm float64, stops int) time.Duration {
+	cruise := time.Duration(distanceKm / cruiseSpeedKmh * float64(time.Hour))
+
+	return cruise + time.Duration(stops+1)*legOverhead + time.Duration(stops)*stopTime
+}
+
+func radians(degrees float64) float64 {
+	return degrees * math.Pi / 180
+}
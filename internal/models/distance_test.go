@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGreatCircleDistanceKm(t *testing.T) {
+	// The earth is modelled as a sphere, which is within 0.5% of the distances
+	// over the ellipsoid.
+	jfk := Airport{IATA: "JFK", Latitude: 40.639801, Longitude: -73.7789}
+	syd := Airport{IATA: "SYD", Latitude: -33.946098, Longitude: 151.177002}
+	nrt := Airport{IATA: "NRT", Latitude: 35.764702, Longitude: 140.386002}
+
+	assert.InEpsilon(t, 16014, GreatCircleDistanceKm(jfk, syd), 0.005)
+	assert.InEpsilon(t, 7823, GreatCircleDistanceKm(syd, nrt), 0.005, "Distances across the equator")
+	assert.InEpsilon(t, 10840, GreatCircleDistanceKm(nrt, jfk), 0.005, "Distances across the antimeridian")
+	assert.Zero(t, GreatCircleDistanceKm(jfk, jfk))
+}
+
+func TestEstimateBlockTime(t *testing.T) {
+	assert.Equal(t, 30*time.Minute, EstimateBlockTime(0, 0))
+	assert.Equal(t, 2*time.Hour+30*time.Minute, EstimateBlockTime(1600, 0))
+	assert.Equal(t, 3*time.Hour+45*time.Minute, EstimateBlockTime(1600, 1))
+}
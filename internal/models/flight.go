@@ -1,12 +1,40 @@
 package models
 
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// DefaultRouteLimit is the page size used when a query does not set a limit.
+const DefaultRouteLimit = 100
+
+// RouteFilters selects routes. Every set filter has to match; list filters
+// match routes with any of their values.
 type RouteFilters struct {
-	Airline            string
-	SourceAirport      string
-	DestinationAirport string
-	MaxStops           *int
-	Limit              int
-	Offset             int
+	Airlines        []string
+	ExcludeAirlines []string
+	// SourceAirports and DestinationAirports are unset when nil; an empty list
+	// that is not nil, such as of an area without airports, matches no route.
+	SourceAirports      []string
+	DestinationAirports []string
+	// CodeShare, when set, is the required code share flag, "Y" or "N".
+	CodeShare string
+	// Equipment matches routes operated with any of the listed aircraft types.
+	Equipment []string
+	MaxStops  *int
+	// MinDistanceKm and MaxDistanceKm bound the great-circle distance of
+	// routes. Routes of unknown distance never match them.
+	MinDistanceKm *int
+	MaxDistanceKm *int
+	// Sort orders the matching routes; without it they are ordered by source,
+	// destination and airline.
+	Sort   []SortKey
+	Limit  int
+	Offset int
+	// Cursor, when set, selects the page from the snapshot it refers to and
+	// takes the place of Offset.
+	Cursor *Cursor
 }
 
 type Route struct {
@@ -17,4 +45,56 @@ type Route struct {
 	Stops              int     `json:"stops"`
 	Equipment          *string `json:"equipment,omitempty"`
 	Provider           string  `json:"provider"`
+	// Providers lists every provider offering the route, in declaration order.
+	Providers []string `json:"providers,omitempty"`
+	// Estimate is derived from the airport catalog, and nil when an airport of
+	// the route is not in it.
+	Estimate *FlightEstimate `json:"-"`
+}
+
+// FlightEstimate describes a route by the great-circle distance between its
+// airports.
+type FlightEstimate struct {
+	DistanceKm float64
+	// BlockTime is the estimated gate to gate time.
+	BlockTime time.Duration
+}
+
+// Match reports whether route passes every filter but the distance bounds,
+// which need the airport catalog, ignoring paging.
+func (f RouteFilters) Match(route Route) bool {
+	switch {
+	case len(f.Airlines) > 0 && !slices.Contains(f.Airlines, route.Airline):
+		return false
+	case slices.Contains(f.ExcludeAirlines, route.Airline):
+		return false
+	case f.SourceAirports != nil && !slices.Contains(f.SourceAirports, route.SourceAirport):
+		return false
+	case f.DestinationAirports != nil && !slices.Contains(f.DestinationAirports, route.DestinationAirport):
+		return false
+	case f.CodeShare != "" && route.CodeShare != f.CodeShare:
+		return false
+	case len(f.Equipment) > 0 && !route.OperatedWith(f.Equipment):
+		return false
+	case f.MaxStops != nil && route.Stops > *f.MaxStops:
+		return false
+	}
+
+	return true
+}
+
+// OperatedWith reports whether any of the aircraft types is in the route's
+// space separated equipment list.
+func (r Route) OperatedWith(aircraft []string) bool {
+	if r.Equipment == nil {
+		return false
+	}
+
+	for _, equipment := range strings.Fields(*r.Equipment) {
+		if slices.Contains(aircraft, equipment) {
+			return true
+		}
+	}
+
+	return false
 }
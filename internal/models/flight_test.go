@@ -0,0 +1,61 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRouteFilters_Match(t *testing.T) {
+	t.Parallel()
+
+	equipment := func(value string) *string { return &value }
+	one := 1
+
+	routes := []Route{
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "N", Stops: 0, Equipment: equipment("737 320")},
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "Y", Stops: 1},
+		{Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", CodeShare: "N", Stops: 0, Equipment: equipment("320")},
+		{Airline: "DL", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "Y", Stops: 2},
+	}
+
+	tests := []struct {
+		name    string
+		filters RouteFilters
+		want    []int
+	}{
+		{"no filters", RouteFilters{}, []int{0, 1, 2, 3}},
+		{"airline", RouteFilters{Airlines: []string{"AA"}}, []int{0, 2}},
+		{"several airlines", RouteFilters{Airlines: []string{"UA", "DL"}}, []int{1, 3}},
+		{"excluded airline", RouteFilters{ExcludeAirlines: []string{"AA"}}, []int{1, 3}},
+		{"source airport", RouteFilters{SourceAirports: []string{"JFK"}}, []int{0, 1, 3}},
+		{"several destination airports", RouteFilters{DestinationAirports: []string{"SFO", "JFK"}}, []int{1, 2}},
+		{"no source airports", RouteFilters{SourceAirports: []string{}}, []int{}},
+		{"max stops", RouteFilters{MaxStops: &one}, []int{0, 1, 2}},
+		{"code share", RouteFilters{CodeShare: "N"}, []int{0, 2}},
+		{"equipment", RouteFilters{Equipment: []string{"737"}}, []int{0}},
+		{"any of several equipment", RouteFilters{Equipment: []string{"320", "777"}}, []int{0, 2}},
+		{
+			"multi-value and exclusion filters",
+			RouteFilters{SourceAirports: []string{"JFK", "LAX"}, ExcludeAirlines: []string{"DL", "UA"}, Equipment: []string{"320"}},
+			[]int{0, 2},
+		},
+		{"paging is ignored", RouteFilters{Airlines: []string{"AA"}, Limit: 1, Offset: 5}, []int{0, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			matched := []int{}
+
+			for i, route := range routes {
+				if tt.filters.Match(route) {
+					matched = append(matched, i)
+				}
+			}
+
+			assert.Equal(t, tt.want, matched)
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package models
+
+import "time"
+
+const (
+	// DefaultItineraryLimit is the number of itineraries returned when a
+	// search does not set a limit.
+	DefaultItineraryLimit = 10
+	// DefaultMaxLegs bounds itineraries when a search does not set MaxLegs.
+	DefaultMaxLegs = 2
+)
+
+// Carrier restricts the airlines an itinerary may combine.
+type Carrier string
+
+const (
+	// CarrierAny allows legs flown by any airlines.
+	CarrierAny Carrier = "any"
+	// CarrierAirline requires every leg to be flown by the same airline.
+	CarrierAirline Carrier = "airline"
+	// CarrierAlliance requires every leg to be flown by the same airline or by
+	// members of one alliance.
+	CarrierAlliance Carrier = "alliance"
+)
+
+type ItineraryQuery struct {
+	SourceAirport      string
+	DestinationAirport string
+	// MaxLegs is the largest number of routes an itinerary may consist of.
+	MaxLegs int
+	Carrier Carrier
+	Limit   int
+}
+
+// Itinerary is a sequence of routes from a source to a destination airport,
+// each leg departing from the airport the previous one arrives at.
+type Itinerary struct {
+	Legs []Route
+	// MinConnectionTime is the sum of the minimum connection times of every
+	// connection between legs.
+	MinConnectionTime time.Duration
+}
+
+type ItinerariesResult struct {
+	Itineraries []Itinerary
+	Providers   ProviderReports
+}
@@ -0,0 +1,69 @@
+package models
+
+import "time"
+
+type ProviderStatus string
+
+const (
+	ProviderStatusOK      ProviderStatus = "ok"
+	ProviderStatusFailed  ProviderStatus = "failed"
+	ProviderStatusStale   ProviderStatus = "stale"
+	ProviderStatusSkipped ProviderStatus = "skipped"
+)
+
+// ProviderReport describes how a single provider contributed to a routes query.
+type ProviderReport struct {
+	Name       string
+	Status     ProviderStatus
+	Latency    time.Duration
+	RouteCount int
+	Error      error
+}
+
+// ProviderReports describes how every configured provider contributed to a query.
+type ProviderReports []ProviderReport
+
+// Stale reports whether any provider served routes past its cache TTL.
+func (r ProviderReports) Stale() bool {
+	for _, report := range r {
+		if report.Status == ProviderStatusStale {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Failed returns the names of the providers that could not be queried.
+func (r ProviderReports) Failed() []string {
+	var names []string
+
+	for _, report := range r {
+		if report.Status == ProviderStatusFailed {
+			names = append(names, report.Name)
+		}
+	}
+
+	return names
+}
+
+type RoutesResult struct {
+	Routes []Route
+	// Total is the number of routes matching the filters, across all pages.
+	Total int
+	// Offset is the position of the page within all matching routes.
+	Offset int
+	// Snapshot identifies the version of provider data the page was taken from.
+	Snapshot  int64
+	Providers ProviderReports
+}
+
+// Stale reports whether any provider served routes past its cache TTL.
+func (r RoutesResult) Stale() bool {
+	return r.Providers.Stale()
+}
+
+// FailedProviders returns the names of the providers that could not be queried.
+func (r RoutesResult) FailedProviders() []string {
+	return r.Providers.Failed()
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Route fields routes can be sorted by, named as in the API.
+const (
+	SortAirline            = "airline"
+	SortSourceAirport      = "sourceAirport"
+	SortDestinationAirport = "destinationAirport"
+	SortCodeShare          = "codeShare"
+	SortStops              = "stops"
+	SortEquipment          = "equipment"
+	SortProvider           = "provider"
+	SortDistanceKm         = "distanceKm"
+)
+
+// SortFields lists every field routes can be sorted by.
+var SortFields = []string{
+	SortAirline, SortSourceAirport, SortDestinationAirport, SortCodeShare, SortStops, SortEquipment, SortProvider,
+	SortDistanceKm,
+}
+
+// SortKey orders routes by one field.
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+func (k SortKey) String() string {
+	if k.Descending {
+		return "-" + k.Field
+	}
+
+	return k.Field
+}
+
+// ParseSort parses a comma separated list of fields, each optionally prefixed
+// with "-" for descending order, e.g. "stops,-airline".
+func ParseSort(s string) ([]SortKey, error) {
+	var keys []SortKey
+
+	for _, field := range strings.Split(s, ",") {
+		key := SortKey{Field: field}
+		if rest, ok := strings.CutPrefix(field, "-"); ok {
+			key = SortKey{Field: rest, Descending: true}
+		}
+
+		if !slices.Contains(SortFields, key.Field) {
+			return nil, fmt.Errorf("unknown sort field %q, must be one of %s",
+				key.Field, strings.Join(SortFields, ", "))
+		}
+
+		if slices.ContainsFunc(keys, func(k SortKey) bool { return k.Field == key.Field }) {
+			return nil, fmt.Errorf("sort field %q is given more than once", key.Field)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,26 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSort(t *testing.T) {
+	keys, err := ParseSort("stops,-airline,sourceAirport")
+
+	require.NoError(t, err)
+	assert.Equal(t, []SortKey{
+		{Field: SortStops},
+		{Field: SortAirline, Descending: true},
+		{Field: SortSourceAirport},
+	}, keys)
+}
+
+func TestParseSort_Invalid(t *testing.T) {
+	for _, value := range []string{"", "price", "stops,", "--stops", "stops,-stops"} {
+		_, err := ParseSort(value)
+		assert.Error(t, err, value)
+	}
+}
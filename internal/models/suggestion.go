@@ -0,0 +1,29 @@
+package models
+
+// DefaultSuggestionLimit is the number of suggestions returned when an
+// autocomplete request does not set a limit.
+const DefaultSuggestionLimit = 10
+
+// SuggestionKind tells what an autocomplete suggestion refers to.
+type SuggestionKind string
+
+const (
+	SuggestionAirport SuggestionKind = "airport"
+	SuggestionMetro   SuggestionKind = "metro"
+)
+
+// Suggestion is an airport or metropolitan area proposed for an autocomplete
+// query.
+type Suggestion struct {
+	Kind SuggestionKind
+	// Code is the IATA code of the airport or metropolitan area.
+	Code string
+	// Name is the airport name, or the city of a metropolitan area.
+	Name    string
+	City    string
+	Country string
+	// Airports lists the airports of a metropolitan area.
+	Airports []string
+	// RouteCount is the number of routes from or to the airports.
+	RouteCount int
+}
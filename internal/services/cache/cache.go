@@ -1,45 +1,268 @@
 package cache
 
 import (
+	"context"
+	"fmt"
 	"sync"
 	"time"
 
+	"flight-booking/internal/config"
+	"flight-booking/internal/services/logger"
 	"github.com/patrickmn/go-cache"
 )
 
+// Failed background refreshes of a stale value are retried after a backoff
+// that doubles with every failure, from minRefreshBackoff up to
+// maxRefreshBackoff.
+const (
+	minRefreshBackoff = time.Second
+	maxRefreshBackoff = time.Minute
+)
+
+// Cache stores loaded values by key. Concurrent misses on the same key share
+// a single in-flight load, while different keys load independently.
 type Cache interface {
-	GetOrLoad(key string, ttl time.Duration, loader func() (any, error)) (any, error)
+	GetOrLoad(ctx context.Context, key string, ttl TTL, loader func(ctx context.Context) (any, error)) (Item, error)
+	// Refresh runs loader and stores its value even if the cached one is still
+	// fresh. It joins a load already in flight for the key.
+	Refresh(ctx context.Context, key string, ttl TTL, loader func(ctx context.Context) (any, error)) error
+}
+
+// TTL controls how long a loaded value is served.
+type TTL struct {
+	// Fresh is the soft TTL. Once it passes the value is still served, but is
+	// refreshed in the background.
+	Fresh time.Duration
+	// MaxStale is how long past Fresh a stale value may be served, both while
+	// it is being refreshed and when refreshing fails. After that the value is
+	// dropped and the next caller waits for the loader.
+	MaxStale time.Duration
+}
+
+// Item is a value returned from the cache.
+type Item struct {
+	Value any
+	// Stale reports whether the value is past its soft TTL.
+	Stale bool
+}
+
+type entry struct {
+	value      any
+	freshUntil time.Time
+}
+
+type call struct {
+	done  chan struct{}
+	value any
+	err   error
+	// background is set for a refresh started while serving a stale value.
+	background bool
+}
+
+// retry delays the next background refresh of a key after failed ones.
+type retry struct {
+	at       time.Time
+	failures int
 }
 
 type inMemoryCache struct {
-	cache *cache.Cache
-	mu    sync.RWMutex
+	cache      *cache.Cache
+	enabled    bool
+	defaultTTL time.Duration
+	maxSize    int
+
+	minBackoff time.Duration
+
+	mu       sync.Mutex
+	inflight map[string]*call
+	retries  map[string]retry
 }
 
-func New() Cache {
+func New(config config.Config) Cache {
 	return &inMemoryCache{
-		cache: cache.New(time.Second, time.Second),
+		cache:      cache.New(config.Cache.DefaultTTL, config.Cache.CleanupInterval),
+		enabled:    config.Cache.Enabled,
+		defaultTTL: config.Cache.DefaultTTL,
+		maxSize:    config.Cache.MaxSize,
+		minBackoff: minRefreshBackoff,
+		inflight:   make(map[string]*call),
+		retries:    make(map[string]retry),
 	}
 }
 
-func (c *inMemoryCache) GetOrLoad(key string, ttl time.Duration, loader func() (any, error)) (any, error) {
-	c.mu.RLock()
-	value, found := c.cache.Get(key)
-	c.mu.RUnlock()
+// GetOrLoad returns the cached value for key or runs loader to fill it. The
+// loader runs detached from the caller's cancellation, so a caller that gives
+// up does not fail the load for everyone else waiting on the same key.
+func (c *inMemoryCache) GetOrLoad(
+	ctx context.Context,
+	key string,
+	ttl TTL,
+	loader func(ctx context.Context) (any, error),
+) (Item, error) {
+	if item, found := c.lookup(key); found && !item.Stale {
+		return item, nil
+	}
 
+	c.mu.Lock()
+
+	// The value may have been stored by a load that finished after the lookup above.
+	item, found := c.lookup(key)
+	if found && !item.Stale {
+		c.mu.Unlock()
+
+		return item, nil
+	}
+
+	// A stale value whose last refresh failed is served without retrying
+	// until the backoff has passed.
+	if found && time.Now().Before(c.retries[key].at) {
+		c.mu.Unlock()
+
+		return item, nil
+	}
+
+	cl := c.startLoad(ctx, key, ttl, loader, found)
+
+	c.mu.Unlock()
+
+	// A stale value is served right away while the refresh runs in the background.
 	if found {
-		return value, nil
+		return item, nil
+	}
+
+	if err := c.wait(ctx, key, cl); err != nil {
+		return Item{}, err
 	}
 
+	return Item{Value: cl.value}, nil
+}
+
+func (c *inMemoryCache) Refresh(
+	ctx context.Context,
+	key string,
+	ttl TTL,
+	loader func(ctx context.Context) (any, error),
+) error {
 	c.mu.Lock()
-	defer c.mu.Unlock()
+	cl := c.startLoad(ctx, key, ttl, loader, false)
+	c.mu.Unlock()
+
+	return c.wait(ctx, key, cl)
+}
 
-	newValue, err := loader()
-	if err != nil {
-		return nil, err
+// startLoad returns the in-flight load for key, starting one if there is
+// none. It must be called with c.mu held.
+func (c *inMemoryCache) startLoad(
+	ctx context.Context,
+	key string,
+	ttl TTL,
+	loader func(ctx context.Context) (any, error),
+	background bool,
+) *call {
+	if cl, ok := c.inflight[key]; ok {
+		return cl
 	}
 
-	c.cache.Set(key, newValue, ttl)
+	cl := &call{done: make(chan struct{}), background: background}
+	c.inflight[key] = cl
+
+	go c.load(context.WithoutCancel(ctx), key, ttl, loader, cl)
+
+	return cl
+}
+
+func (c *inMemoryCache) wait(ctx context.Context, key string, cl *call) error {
+	select {
+	case <-cl.done:
+		return cl.err
+	case <-ctx.Done():
+		return fmt.Errorf("waiting for %s: %w", key, ctx.Err())
+	}
+}
+
+func (c *inMemoryCache) lookup(key string) (Item, bool) {
+	value, found := c.cache.Get(key)
+	if !found {
+		return Item{}, false
+	}
+
+	e, ok := value.(entry)
+	if !ok {
+		return Item{}, false
+	}
+
+	return Item{
+		Value: e.value,
+		Stale: time.Now().After(e.freshUntil),
+	}, true
+}
+
+func (c *inMemoryCache) load(
+	ctx context.Context,
+	key string,
+	ttl TTL,
+	loader func(ctx context.Context) (any, error),
+	cl *call,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			cl.value = nil
+			cl.err = fmt.Errorf("loader for %s panicked: %v", key, r)
+		}
+
+		var backoff time.Duration
+
+		c.mu.Lock()
+		if cl.err == nil {
+			c.store(key, ttl, cl.value)
+			delete(c.retries, key)
+		} else if cl.background {
+			backoff = c.backOff(key)
+		}
+
+		delete(c.inflight, key)
+		c.mu.Unlock()
+
+		// Nobody waits for a background refresh, so its failure is reported here.
+		if backoff > 0 {
+			logger.Context(ctx).Warn("background cache refresh failed, serving stale value",
+				"key", key, "retry_in", backoff, "error", cl.err)
+		}
+
+		close(cl.done)
+	}()
+
+	cl.value, cl.err = loader(ctx)
+}
+
+// backOff records a failed background refresh of key and returns how long to
+// wait before the next one. It must be called with c.mu held.
+func (c *inMemoryCache) backOff(key string) time.Duration {
+	r := c.retries[key]
+	backoff := min(c.minBackoff<<min(r.failures, 16), maxRefreshBackoff)
+
+	c.retries[key] = retry{at: time.Now().Add(backoff), failures: r.failures + 1}
+
+	return backoff
+}
+
+// store saves a loaded value unless caching is disabled or the cache is full.
+// Values without a TTL of their own stay fresh for the default TTL.
+func (c *inMemoryCache) store(key string, ttl TTL, value any) {
+	if !c.enabled {
+		return
+	}
+
+	if _, exists := c.cache.Get(key); !exists && c.maxSize > 0 && c.cache.ItemCount() >= c.maxSize {
+		return
+	}
+
+	if ttl.Fresh <= 0 {
+		ttl.Fresh = c.defaultTTL
+	}
 
-	return newValue, nil
+	c.cache.Set(key, entry{
+		value:      value,
+		freshUntil: time.Now().Add(ttl.Fresh),
+	}, ttl.Fresh+ttl.MaxStale)
 }
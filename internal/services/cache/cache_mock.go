@@ -3,7 +3,7 @@
 package cache
 
 import (
-	time "time"
+	context "context"
 
 	mock "github.com/stretchr/testify/mock"
 )
@@ -21,29 +21,27 @@ func (_m *MockCache) EXPECT() *MockCache_Expecter {
 	return &MockCache_Expecter{mock: &_m.Mock}
 }
 
-// GetOrLoad provides a mock function with given fields: key, ttl, loader
-func (_m *MockCache) GetOrLoad(key string, ttl time.Duration, loader func() (interface{}, error)) (interface{}, error) {
-	ret := _m.Called(key, ttl, loader)
+// GetOrLoad provides a mock function with given fields: ctx, key, ttl, loader
+func (_m *MockCache) GetOrLoad(ctx context.Context, key string, ttl TTL, loader func(context.Context) (interface{}, error)) (Item, error) {
+	ret := _m.Called(ctx, key, ttl, loader)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetOrLoad")
 	}
 
-	var r0 interface{}
+	var r0 Item
 	var r1 error
-	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) (interface{}, error)); ok {
-		return rf(key, ttl, loader)
+	if rf, ok := ret.Get(0).(func(context.Context, string, TTL, func(context.Context) (interface{}, error)) (Item, error)); ok {
+		return rf(ctx, key, ttl, loader)
 	}
-	if rf, ok := ret.Get(0).(func(string, time.Duration, func() (interface{}, error)) interface{}); ok {
-		r0 = rf(key, ttl, loader)
+	if rf, ok := ret.Get(0).(func(context.Context, string, TTL, func(context.Context) (interface{}, error)) Item); ok {
+		r0 = rf(ctx, key, ttl, loader)
 	} else {
-		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(interface{})
-		}
+		r0 = ret.Get(0).(Item)
 	}
 
-	if rf, ok := ret.Get(1).(func(string, time.Duration, func() (interface{}, error)) error); ok {
-		r1 = rf(key, ttl, loader)
+	if rf, ok := ret.Get(1).(func(context.Context, string, TTL, func(context.Context) (interface{}, error)) error); ok {
+		r1 = rf(ctx, key, ttl, loader)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -57,26 +55,76 @@ type MockCache_GetOrLoad_Call struct {
 }
 
 // GetOrLoad is a helper method to define mock.On call
+//   - ctx context.Context
 //   - key string
-//   - ttl time.Duration
-//   - loader func()(interface{} , error)
-func (_e *MockCache_Expecter) GetOrLoad(key interface{}, ttl interface{}, loader interface{}) *MockCache_GetOrLoad_Call {
-	return &MockCache_GetOrLoad_Call{Call: _e.mock.On("GetOrLoad", key, ttl, loader)}
+//   - ttl TTL
+//   - loader func(context.Context)(interface{} , error)
+func (_e *MockCache_Expecter) GetOrLoad(ctx interface{}, key interface{}, ttl interface{}, loader interface{}) *MockCache_GetOrLoad_Call {
+	return &MockCache_GetOrLoad_Call{Call: _e.mock.On("GetOrLoad", ctx, key, ttl, loader)}
 }
 
-func (_c *MockCache_GetOrLoad_Call) Run(run func(key string, ttl time.Duration, loader func() (interface{}, error))) *MockCache_GetOrLoad_Call {
+func (_c *MockCache_GetOrLoad_Call) Run(run func(ctx context.Context, key string, ttl TTL, loader func(context.Context) (interface{}, error))) *MockCache_GetOrLoad_Call {
 	_c.Call.Run(func(args mock.Arguments) {
-		run(args[0].(string), args[1].(time.Duration), args[2].(func() (interface{}, error)))
+		run(args[0].(context.Context), args[1].(string), args[2].(TTL), args[3].(func(context.Context) (interface{}, error)))
 	})
 	return _c
 }
 
-func (_c *MockCache_GetOrLoad_Call) Return(_a0 interface{}, _a1 error) *MockCache_GetOrLoad_Call {
+func (_c *MockCache_GetOrLoad_Call) Return(_a0 Item, _a1 error) *MockCache_GetOrLoad_Call {
 	_c.Call.Return(_a0, _a1)
 	return _c
 }
 
-func (_c *MockCache_GetOrLoad_Call) RunAndReturn(run func(string, time.Duration, func() (interface{}, error)) (interface{}, error)) *MockCache_GetOrLoad_Call {
+func (_c *MockCache_GetOrLoad_Call) RunAndReturn(run func(context.Context, string, TTL, func(context.Context) (interface{}, error)) (Item, error)) *MockCache_GetOrLoad_Call {
+	_c.Call.Return(run)
+	return _c
+}
+
+// Refresh provides a mock function with given fields: ctx, key, ttl, loader
+func (_m *MockCache) Refresh(ctx context.Context, key string, ttl TTL, loader func(context.Context) (interface{}, error)) error {
+	ret := _m.Called(ctx, key, ttl, loader)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Refresh")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, TTL, func(context.Context) (interface{}, error)) error); ok {
+		r0 = rf(ctx, key, ttl, loader)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// MockCache_Refresh_Call is a *mock.Call that shadows Run/Return methods with type explicit version for method 'Refresh'
+type MockCache_Refresh_Call struct {
+	*mock.Call
+}
+
+// Refresh is a helper method to define mock.On call
+//   - ctx context.Context
+//   - key string
+//   - ttl TTL
+//   - loader func(context.Context)(interface{} , error)
+func (_e *MockCache_Expecter) Refresh(ctx interface{}, key interface{}, ttl interface{}, loader interface{}) *MockCache_Refresh_Call {
+	return &MockCache_Refresh_Call{Call: _e.mock.On("Refresh", ctx, key, ttl, loader)}
+}
+
+func (_c *MockCache_Refresh_Call) Run(run func(ctx context.Context, key string, ttl TTL, loader func(context.Context) (interface{}, error))) *MockCache_Refresh_Call {
+	_c.Call.Run(func(args mock.Arguments) {
+		run(args[0].(context.Context), args[1].(string), args[2].(TTL), args[3].(func(context.Context) (interface{}, error)))
+	})
+	return _c
+}
+
+func (_c *MockCache_Refresh_Call) Return(_a0 error) *MockCache_Refresh_Call {
+	_c.Call.Return(_a0)
+	return _c
+}
+
+func (_c *MockCache_Refresh_Call) RunAndReturn(run func(context.Context, string, TTL, func(context.Context) (interface{}, error)) error) *MockCache_Refresh_Call {
 	_c.Call.Return(run)
 	return _c
 }
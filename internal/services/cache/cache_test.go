@@ -0,0 +1,386 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"flight-booking/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testConfig = config.Config{
+	Cache: config.CacheConfig{
+		Enabled:         true,
+		DefaultTTL:      time.Minute,
+		CleanupInterval: time.Second,
+	},
+}
+
+func freshFor(ttl time.Duration) TTL {
+	return TTL{Fresh: ttl}
+}
+
+func TestCache_GetOrLoad_CachesValue(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+	calls := 0
+
+	loader := func(context.Context) (any, error) {
+		calls++
+
+		return "value", nil
+	}
+
+	for range 3 {
+		value, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), loader)
+		require.NoError(t, err)
+		assert.Equal(t, "value", value.Value)
+	}
+
+	assert.Equal(t, 1, calls)
+}
+
+func TestCache_GetOrLoad_DoesNotCacheErrors(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+
+	_, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), func(context.Context) (any, error) {
+		return nil, errors.New("boom")
+	})
+	require.Error(t, err)
+
+	value, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), func(context.Context) (any, error) {
+		return "value", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "value", value.Value)
+}
+
+func TestCache_GetOrLoad_ConcurrentMissesShareOneLoad(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+
+	var calls atomic.Int32
+
+	release := make(chan struct{})
+	loader := func(context.Context) (any, error) {
+		calls.Add(1)
+		<-release
+
+		return "value", nil
+	}
+
+	const callers = 50
+
+	var wg sync.WaitGroup
+
+	results := make(chan Item, callers)
+
+	for range callers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			value, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), loader)
+			assert.NoError(t, err)
+
+			results <- value
+		}()
+	}
+
+	// Give every caller a chance to join the in-flight load before it completes.
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	assert.Equal(t, int32(1), calls.Load())
+
+	for value := range results {
+		assert.Equal(t, "value", value.Value)
+	}
+}
+
+func TestCache_GetOrLoad_DifferentKeysLoadIndependently(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	go func() {
+		_, _ = c.GetOrLoad(context.Background(), "slow", freshFor(time.Minute), func(context.Context) (any, error) {
+			<-release
+
+			return "slow", nil
+		})
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	value, err := c.GetOrLoad(ctx, "fast", freshFor(time.Minute), func(context.Context) (any, error) {
+		return "fast", nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "fast", value.Value)
+}
+
+func TestCache_GetOrLoad_PanickingLoaderDoesNotPoisonKey(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+
+	release := make(chan struct{})
+	errs := make(chan error, 2)
+
+	for range 2 {
+		go func() {
+			_, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), func(context.Context) (any, error) {
+				<-release
+
+				panic("loader exploded")
+			})
+			errs <- err
+		}()
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	close(release)
+
+	for range 2 {
+		err := <-errs
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "panicked")
+	}
+
+	value, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), func(context.Context) (any, error) {
+		return "value", nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "value", value.Value)
+}
+
+func TestCache_GetOrLoad_CancelledCallerDoesNotFailWaiters(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+
+	release := make(chan struct{})
+	loaderCtxErr := make(chan error, 1)
+
+	loader := func(ctx context.Context) (any, error) {
+		<-release
+		loaderCtxErr <- ctx.Err()
+
+		return "value", nil
+	}
+
+	firstCtx, cancelFirst := context.WithCancel(t.Context())
+	firstErr := make(chan error, 1)
+
+	go func() {
+		_, err := c.GetOrLoad(firstCtx, "key", freshFor(time.Minute), loader)
+		firstErr <- err
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+
+	secondResult := make(chan Item, 1)
+
+	go func() {
+		value, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), loader)
+		assert.NoError(t, err)
+
+		secondResult <- value
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	cancelFirst()
+	require.ErrorIs(t, <-firstErr, context.Canceled)
+
+	close(release)
+
+	assert.Equal(t, "value", (<-secondResult).Value)
+	assert.NoError(t, <-loaderCtxErr, "Loader should not observe the first caller's cancellation")
+}
+
+func TestCache_GetOrLoad_ServesStaleWhileRevalidating(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+	ttl := TTL{Fresh: 50 * time.Millisecond, MaxStale: time.Minute}
+
+	_, err := c.GetOrLoad(t.Context(), "key", ttl, func(context.Context) (any, error) {
+		return "old", nil
+	})
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	release := make(chan struct{})
+	refreshed := make(chan struct{})
+
+	item, err := c.GetOrLoad(t.Context(), "key", ttl, func(context.Context) (any, error) {
+		defer close(refreshed)
+
+		<-release
+
+		return "new", nil
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "old", item.Value, "Stale value should be served without waiting for the refresh")
+	assert.True(t, item.Stale)
+
+	close(release)
+	<-refreshed
+
+	require.Eventually(t, func() bool {
+		item, err := c.GetOrLoad(t.Context(), "key", ttl, func(context.Context) (any, error) {
+			return "unexpected", nil
+		})
+
+		return err == nil && item.Value == "new" && !item.Stale
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestCache_GetOrLoad_ServesStaleWhenRefreshFails(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+	ttl := TTL{Fresh: 50 * time.Millisecond, MaxStale: time.Minute}
+
+	_, err := c.GetOrLoad(t.Context(), "key", ttl, func(context.Context) (any, error) {
+		return "old", nil
+	})
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	failing := func(context.Context) (any, error) {
+		return nil, errors.New("provider down")
+	}
+
+	for range 3 {
+		item, err := c.GetOrLoad(t.Context(), "key", ttl, failing)
+		require.NoError(t, err)
+		assert.Equal(t, "old", item.Value)
+		assert.True(t, item.Stale)
+
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestCache_GetOrLoad_BacksOffFailedRefreshes(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+	c.(*inMemoryCache).minBackoff = 100 * time.Millisecond
+	ttl := TTL{Fresh: 20 * time.Millisecond, MaxStale: time.Minute}
+
+	_, err := c.GetOrLoad(t.Context(), "key", ttl, func(context.Context) (any, error) {
+		return "old", nil
+	})
+	require.NoError(t, err)
+
+	time.Sleep(50 * time.Millisecond)
+
+	var calls atomic.Int32
+
+	failing := func(context.Context) (any, error) {
+		calls.Add(1)
+
+		return nil, errors.New("provider down")
+	}
+
+	for range 5 {
+		item, err := c.GetOrLoad(t.Context(), "key", ttl, failing)
+		require.NoError(t, err)
+		assert.Equal(t, "old", item.Value)
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	assert.Equal(t, int32(1), calls.Load(), "A failed refresh should not be retried before the backoff passes")
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = c.GetOrLoad(t.Context(), "key", ttl, failing)
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return calls.Load() == 2 }, time.Second, 10*time.Millisecond,
+		"The refresh should be retried once the backoff has passed")
+}
+
+func TestCache_GetOrLoad_DropsValuePastMaxStale(t *testing.T) {
+	t.Parallel()
+
+	c := New(testConfig)
+	ttl := TTL{Fresh: 20 * time.Millisecond, MaxStale: 30 * time.Millisecond}
+
+	_, err := c.GetOrLoad(t.Context(), "key", ttl, func(context.Context) (any, error) {
+		return "old", nil
+	})
+	require.NoError(t, err)
+
+	time.Sleep(100 * time.Millisecond)
+
+	_, err = c.GetOrLoad(t.Context(), "key", ttl, func(context.Context) (any, error) {
+		return nil, errors.New("provider down")
+	})
+	require.Error(t, err)
+}
+
+func TestCache_GetOrLoad_Disabled(t *testing.T) {
+	t.Parallel()
+
+	c := New(config.Config{})
+	calls := 0
+
+	for range 2 {
+		item, err := c.GetOrLoad(t.Context(), "key", freshFor(time.Minute), func(context.Context) (any, error) {
+			calls++
+
+			return "value", nil
+		})
+		require.NoError(t, err)
+		assert.Equal(t, "value", item.Value)
+	}
+
+	assert.Equal(t, 2, calls)
+}
+
+func TestCache_GetOrLoad_MaxSize(t *testing.T) {
+	t.Parallel()
+
+	cfg := testConfig
+	cfg.Cache.MaxSize = 1
+	c := New(cfg)
+	calls := 0
+
+	loader := func(context.Context) (any, error) {
+		calls++
+
+		return "value", nil
+	}
+
+	for _, key := range []string{"first", "second", "first", "second"} {
+		_, err := c.GetOrLoad(t.Context(), key, freshFor(time.Minute), loader)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 3, calls, "Only the first key should be cached once the cache is full")
+}
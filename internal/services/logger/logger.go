@@ -34,24 +34,18 @@ type logger struct {
 	logger *zap.Logger
 }
 
-func New(config config.Config) (Logger, error) {
-	var level zapcore.Level
+// New creates the application logger. The log level follows configuration
+// reloads; the format is fixed at startup.
+func New(live *config.Live) (Logger, error) {
+	cfg := live.Get()
+	level := zap.NewAtomicLevelAt(parseLevel(cfg.Log.Level))
 
-	switch config.Log.Level {
-	case "debug":
-		level = zapcore.DebugLevel
-	case "info":
-		level = zapcore.InfoLevel
-	case "warn":
-		level = zapcore.WarnLevel
-	case "error":
-		level = zapcore.ErrorLevel
-	default:
-		level = zapcore.InfoLevel
-	}
+	live.Subscribe(func(cfg config.Config) {
+		level.SetLevel(parseLevel(cfg.Log.Level))
+	})
 
 	var encoderConfig zapcore.EncoderConfig
-	if config.Log.Format == "json" {
+	if cfg.Log.Format == "json" {
 		encoderConfig = zap.NewProductionEncoderConfig()
 	} else {
 		encoderConfig = zap.NewDevelopmentEncoderConfig()
@@ -61,7 +55,7 @@ func New(config config.Config) (Logger, error) {
 	encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 
 	var encoder zapcore.Encoder
-	if config.Log.Format == "json" {
+	if cfg.Log.Format == "json" {
 		encoder = zapcore.NewJSONEncoder(encoderConfig)
 	} else {
 		encoder = zapcore.NewConsoleEncoder(encoderConfig)
@@ -78,6 +72,21 @@ func New(config config.Config) (Logger, error) {
 	}, nil
 }
 
+func parseLevel(level string) zapcore.Level {
+	switch level {
+	case "debug":
+		return zapcore.DebugLevel
+	case "info":
+		return zapcore.InfoLevel
+	case "warn":
+		return zapcore.WarnLevel
+	case "error":
+		return zapcore.ErrorLevel
+	default:
+		return zapcore.InfoLevel
+	}
+}
+
 func (l *logger) SetIntoContext(ctx context.Context) context.Context {
 	return context.WithValue(ctx, ctxKey{}, l)
 }
@@ -0,0 +1,85 @@
+package providers
+
+import (
+	"fmt"
+	"testing"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/routestore"
+)
+
+// benchmarkRoutes returns n routes spread over 500 airports and 60 airlines.
+func benchmarkRoutes(n int) []models.Route {
+	routes := make([]models.Route, n)
+	for i := range routes {
+		routes[i] = models.Route{
+			Airline:            fmt.Sprintf("A%d", i%60),
+			SourceAirport:      fmt.Sprintf("S%03d", i%500),
+			DestinationAirport: fmt.Sprintf("D%03d", (i/500)%500),
+			CodeShare:          "N",
+			Stops:              i % 3,
+		}
+	}
+
+	return routes
+}
+
+var benchmarkFilters = []struct {
+	name    string
+	filters models.RouteFilters
+}{
+	{"Unfiltered", models.RouteFilters{}},
+	{"Source", models.RouteFilters{SourceAirports: []string{"S042"}}},
+	{"SourceAndAirline", models.RouteFilters{SourceAirports: []string{"S042"}, Airlines: []string{"A2"}}},
+	{"SourceAndDest", models.RouteFilters{SourceAirports: []string{"S042"}, DestinationAirports: []string{"D007"}}},
+	{"SourceAndMaxStops", models.RouteFilters{SourceAirports: []string{"S042"}, MaxStops: new(int)}},
+}
+
+// scanRoutes returns the page of routes matching filters by scanning every
+// route, the baseline the route store is measured against.
+func scanRoutes(filters models.RouteFilters, routes []models.Route, offset, limit int) []models.Route {
+	page := make([]models.Route, 0, limit)
+	matched := 0
+
+	for _, route := range routes {
+		if !filters.Match(route) {
+			continue
+		}
+
+		matched++
+		if matched <= offset {
+			continue
+		}
+
+		page = append(page, route)
+		if len(page) == limit {
+			break
+		}
+	}
+
+	return page
+}
+
+func BenchmarkScan(b *testing.B) {
+	routes := benchmarkRoutes(100_000)
+
+	for _, bm := range benchmarkFilters {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				scanRoutes(bm.filters, routes, 20, 100)
+			}
+		})
+	}
+}
+
+func BenchmarkRouteStore(b *testing.B) {
+	store := routestore.New(benchmarkRoutes(100_000))
+
+	for _, bm := range benchmarkFilters {
+		b.Run(bm.name, func(b *testing.B) {
+			for b.Loop() {
+				store.Query(bm.filters).Slice(20, 100)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+package providers
+
+import (
+	"slices"
+	"strings"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+)
+
+type routeKey struct {
+	airline     string
+	source      string
+	destination string
+}
+
+// mergeRoutes combines the routes of every provider, given in declaration
+// order, into one list. Unless merging is disabled, routes sharing an airline,
+// source and destination are collapsed into the first one seen, with
+// conflicting fields resolved by the configured rules. The provider routes are
+// not modified, as they are shared with the cache.
+func mergeRoutes(rules config.MergeConfig, names []string, routes [][]models.Route) []models.Route {
+	total := 0
+	for _, providerRoutes := range routes {
+		total += len(providerRoutes)
+	}
+
+	merged := make([]models.Route, 0, total)
+	index := make(map[routeKey]int, total)
+
+	for i, providerRoutes := range routes {
+		for _, route := range providerRoutes {
+			key := routeKey{airline: route.Airline, source: route.SourceAirport, destination: route.DestinationAirport}
+
+			at, seen := index[key]
+			if !rules.Enabled || !seen {
+				route.Providers = []string{names[i]}
+				index[key] = len(merged)
+				merged = append(merged, route)
+
+				continue
+			}
+
+			existing := &merged[at]
+			if !slices.Contains(existing.Providers, names[i]) {
+				existing.Providers = append(existing.Providers, names[i])
+			}
+
+			mergeConflicts(rules, existing, route)
+		}
+	}
+
+	return merged
+}
+
+func mergeConflicts(rules config.MergeConfig, existing *models.Route, route models.Route) {
+	switch rules.Stops {
+	case config.MergeMin:
+		existing.Stops = min(existing.Stops, route.Stops)
+	case config.MergeMax:
+		existing.Stops = max(existing.Stops, route.Stops)
+	}
+
+	switch rules.CodeShare {
+	case config.MergeAny:
+		if route.CodeShare == "Y" {
+			existing.CodeShare = "Y"
+		}
+	case config.MergeAll:
+		if route.CodeShare != "Y" {
+			existing.CodeShare = "N"
+		}
+	}
+
+	switch {
+	case route.Equipment == nil:
+	case existing.Equipment == nil:
+		existing.Equipment = route.Equipment
+	case rules.Equipment == config.MergeUnion:
+		equipment := unionEquipment(*existing.Equipment, *route.Equipment)
+		existing.Equipment = &equipment
+	}
+}
+
+// unionEquipment combines two space separated lists of aircraft types,
+// keeping the order they first appear in.
+func unionEquipment(a, b string) string {
+	types := strings.Fields(a)
+
+	for _, aircraft := range strings.Fields(b) {
+		if !slices.Contains(types, aircraft) {
+			types = append(types, aircraft)
+		}
+	}
+
+	return strings.Join(types, " ")
+}
@@ -0,0 +1,121 @@
+package providers
+
+import (
+	"testing"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMergeRoutes(t *testing.T) {
+	t.Parallel()
+
+	equipment := func(value string) *string { return &value }
+
+	provider1 := []models.Route{
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "N", Stops: 1, Equipment: equipment("737 320"), Provider: "provider1"},
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "N", Stops: 0, Provider: "provider1"},
+	}
+	provider2 := []models.Route{
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "Y", Stops: 0, Equipment: equipment("320 777"), Provider: "provider2"},
+		{Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", CodeShare: "N", Stops: 0, Provider: "provider2"},
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "N", Stops: 0, Equipment: equipment("757"), Provider: "provider2"},
+	}
+
+	tests := []struct {
+		name  string
+		rules config.MergeConfig
+		want  []models.Route
+	}{
+		{
+			name:  "first provider wins",
+			rules: config.MergeConfig{Enabled: true, Stops: config.MergeFirst, CodeShare: config.MergeFirst, Equipment: config.MergeFirst},
+			want: []models.Route{
+				{
+					Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "N", Stops: 1,
+					Equipment: equipment("737 320"), Provider: "provider1", Providers: []string{"provider1", "provider2"},
+				},
+				{
+					Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "N", Stops: 0,
+					Equipment: equipment("757"), Provider: "provider1", Providers: []string{"provider1", "provider2"},
+				},
+				{
+					Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", CodeShare: "N", Stops: 0,
+					Provider: "provider2", Providers: []string{"provider2"},
+				},
+			},
+		},
+		{
+			name:  "min stops, any code share, equipment union",
+			rules: config.MergeConfig{Enabled: true, Stops: config.MergeMin, CodeShare: config.MergeAny, Equipment: config.MergeUnion},
+			want: []models.Route{
+				{
+					Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "Y", Stops: 0,
+					Equipment: equipment("737 320 777"), Provider: "provider1", Providers: []string{"provider1", "provider2"},
+				},
+				{
+					Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "N", Stops: 0,
+					Equipment: equipment("757"), Provider: "provider1", Providers: []string{"provider1", "provider2"},
+				},
+				{
+					Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", CodeShare: "N", Stops: 0,
+					Provider: "provider2", Providers: []string{"provider2"},
+				},
+			},
+		},
+		{
+			name:  "max stops, all code share",
+			rules: config.MergeConfig{Enabled: true, Stops: config.MergeMax, CodeShare: config.MergeAll, Equipment: config.MergeFirst},
+			want: []models.Route{
+				{
+					Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "N", Stops: 1,
+					Equipment: equipment("737 320"), Provider: "provider1", Providers: []string{"provider1", "provider2"},
+				},
+				{
+					Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "N", Stops: 0,
+					Equipment: equipment("757"), Provider: "provider1", Providers: []string{"provider1", "provider2"},
+				},
+				{
+					Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", CodeShare: "N", Stops: 0,
+					Provider: "provider2", Providers: []string{"provider2"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := mergeRoutes(tt.rules, []string{"provider1", "provider2"}, [][]models.Route{provider1, provider2})
+
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	assert.Equal(t, "737 320", *provider1[0].Equipment, "Provider routes should not be modified")
+	assert.Nil(t, provider1[0].Providers)
+}
+
+func TestMergeRoutes_Disabled(t *testing.T) {
+	t.Parallel()
+
+	routes := createMockRoutes("provider1")
+
+	got := mergeRoutes(config.MergeConfig{}, []string{"provider1", "provider2"}, [][]models.Route{routes, routes})
+
+	assert.Len(t, got, 4)
+	assert.Equal(t, []string{"provider2"}, got[3].Providers)
+}
+
+func TestMergeRoutes_DuplicatesWithinProvider(t *testing.T) {
+	t.Parallel()
+
+	routes := createMockRoutes("provider1")
+
+	got := mergeRoutes(config.MergeConfig{Enabled: true}, []string{"provider1"}, [][]models.Route{append(routes, routes...)})
+
+	assert.Len(t, got, 2)
+	assert.Equal(t, []string{"provider1"}, got[0].Providers)
+}
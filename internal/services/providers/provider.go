@@ -2,181 +2,233 @@ package providers
 
 import (
 	"context"
-	"errors"
+	"crypto/sha256"
+	"encoding/json"
 	"fmt"
-	"net/http"
+	"time"
 
+	"flight-booking/internal/apperrors"
 	"flight-booking/internal/config"
 	"flight-booking/internal/models"
 	"flight-booking/internal/services/cache"
 	"flight-booking/internal/services/logger"
-	"resty.dev/v3"
-)
-
-const (
-	defaultRetryCount = 3
 )
 
 type Provider interface {
-	GetRoutes(ctx context.Context, filters models.RouteFilters) ([]models.Route, error)
+	GetRoutes(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error)
 }
 
 type provider struct {
-	config          config.Config
-	cache           cache.Cache
-	provider1Client *resty.Client
-	provider2Client *resty.Client
+	config   *config.Live
+	cache    cache.Cache
+	registry *Registry
+	stores   *Snapshots
 }
 
-func New(config config.Config, cache cache.Cache) Provider {
-	p := provider{
-		config: config,
-		cache:  cache,
+func New(config *config.Live, cache cache.Cache, registry *Registry, snapshots *Snapshots) Provider {
+	return provider{
+		config:   config,
+		cache:    cache,
+		registry: registry,
+		stores:   snapshots,
 	}
+}
 
-	p.provider1Client = resty.New().
-		SetBaseURL(config.Providers.Provider1BaseURL).
-		SetTimeout(config.Providers.Provider1Timeout).
-		SetRetryCount(defaultRetryCount).
-		SetCircuitBreaker(resty.NewCircuitBreaker())
-
-	p.provider2Client = resty.New().
-		SetBaseURL(config.Providers.Provider2BaseURL).
-		SetTimeout(config.Providers.Provider2Timeout).
-		SetRetryCount(defaultRetryCount).
-		SetCircuitBreaker(resty.NewCircuitBreaker())
-
-	return p
+type providerResult struct {
+	index   int
+	routes  providerRoutes
+	stale   bool
+	latency time.Duration
+	err     error
 }
 
-func (p provider) GetRoutes(ctx context.Context, filters models.RouteFilters) ([]models.Route, error) {
-	var routes []models.Route
+// GetRoutes queries all enabled providers concurrently. The whole fan-out is
+// bounded by the providers request timeout; the routes that providers which
+// have not answered by then contributed to the current snapshot are served
+// in their place and reported as stale, and providers without any are
+// reported as failed.
+func (p provider) GetRoutes(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+	entries := p.registry.Entries()
+	cfg := p.config.Get()
 
-	routes1, err := p.routesFromProvider1(ctx)
-	if err != nil {
-		logger.Context(ctx).Error("error fetching routes from provider1", "error", err)
-	}
+	if timeout := cfg.Aggregator.RequestTimeout; timeout > 0 {
+		var cancel context.CancelFunc
 
-	routes = append(routes, routes1...)
-
-	routes2, err := p.routesFromProvider2(ctx)
-	if err != nil {
-		logger.Context(ctx).Error("error fetching routes from provider2", "error", err)
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
 	}
 
-	routes = append(routes, routes2...)
-
-	return p.ApplyFilters(filters, routes), nil
-}
-
-func (p provider) routesFromProvider1(ctx context.Context) ([]models.Route, error) { //nolint:dupl
-	data, err := p.cache.GetOrLoad("provider1_routes", p.config.Providers.Provider1CacheTTL, func() (interface{}, error) {
-		var res []models.Route
+	start := time.Now()
+	results := make(chan providerResult, len(entries))
+	reports := make([]models.ProviderReport, len(entries))
+	inputs := make([]storeInput, len(entries))
+	pending := 0
 
-		resp, err := p.provider1Client.R().
-			SetContext(ctx).
-			SetResult(&res).
-			Get("")
-		if err != nil {
-			return nil, fmt.Errorf("provider1 request failed: %w", err)
+	for i, entry := range entries {
+		reports[i] = models.ProviderReport{
+			Name:   entry.Config.Name,
+			Status: models.ProviderStatusSkipped,
 		}
+		inputs[i].name = entry.Config.Name
 
-		if resp.StatusCode() != http.StatusOK {
-			return nil, fmt.Errorf("provider1 request failed: %s", resp.String())
+		if !entry.Config.Enabled {
+			continue
 		}
 
-		return res, nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error fetching routes from cache or provider1: %w", err)
-	}
+		pending++
 
-	if routes, ok := data.([]models.Route); ok {
-		return routes, nil
+		go func() {
+			started := time.Now()
+			routes, stale, err := p.routesFrom(ctx, entry)
+			results <- providerResult{index: i, routes: routes, stale: stale, latency: time.Since(started), err: err}
+		}()
 	}
 
-	return nil, errors.New("unexpected data type from cache for provider1 routes")
-}
+	answered := make([]bool, len(entries))
 
-func (p provider) routesFromProvider2(ctx context.Context) ([]models.Route, error) { //nolint:dupl
-	data, err := p.cache.GetOrLoad("provider2_routes", p.config.Providers.Provider2CacheTTL, func() (interface{}, error) {
-		var res []models.Route
+collect:
+	for ; pending > 0; pending-- {
+		select {
+		case res := <-results:
+			answered[res.index] = true
+			report := &reports[res.index]
+			report.Latency = res.latency
 
-		resp, err := p.provider2Client.R().
-			SetContext(ctx).
-			SetResult(&res).
-			Get("")
-		if err != nil {
-			return nil, fmt.Errorf("provider2 request failed: %w", err)
-		}
+			if res.err != nil {
+				logger.Context(ctx).Error("error fetching routes from provider",
+					"provider", report.Name, "error", res.err)
 
-		if resp.StatusCode() != http.StatusOK {
-			return nil, fmt.Errorf("provider2 request failed: %s", resp.String())
-		}
+				report.Status = models.ProviderStatusFailed
+				report.Error = res.err
 
-		return res, nil
-	})
-	if err != nil {
-		return nil, fmt.Errorf("error fetching routes from cache or provider2: %w", err)
-	}
+				continue
+			}
 
-	if routes, ok := data.([]models.Route); ok {
-		return routes, nil
+			report.Status = models.ProviderStatusOK
+			if res.stale {
+				report.Status = models.ProviderStatusStale
+			}
+
+			report.RouteCount = len(res.routes.routes)
+			inputs[res.index] = storeInput{name: report.Name, routes: res.routes, ok: true}
+		case <-ctx.Done():
+			break collect
+		}
 	}
 
-	return nil, errors.New("unexpected data type from cache for provider2 routes")
-}
+	missing := make([]bool, len(entries))
 
-func (p provider) ApplyFilters(filters models.RouteFilters, routes []models.Route) []models.Route {
-	if len(routes) == 0 {
-		return routes
-	}
+	for i, entry := range entries {
+		if entry.Config.Enabled && !answered[i] {
+			missing[i] = true
+
+			logger.Context(ctx).Warn("provider did not respond within request timeout", "provider", entry.Config.Name)
 
-	if filters.Limit == 0 {
-		filters.Limit = 100
+			reports[i].Status = models.ProviderStatusFailed
+			reports[i].Latency = time.Since(start)
+			reports[i].Error = fmt.Errorf("%s did not respond in time: %w", entry.Config.Name, ctx.Err())
+		}
 	}
 
-	filtered := make([]models.Route, 0, len(routes))
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = models.DefaultRouteLimit
+	}
 
-	skipped := 0
+	snap := p.stores.get(cfg.Aggregator, inputs, missing)
 
-	for _, route := range routes {
-		if filters.Limit > 0 && len(filtered) >= filters.Limit {
-			break
+	for i := range missing {
+		if input := snap.inputs[i]; missing[i] && input.ok {
+			reports[i].Status = models.ProviderStatusStale
+			reports[i].RouteCount = len(input.routes.routes)
 		}
+	}
 
-		if !p.matchedFilters(filters, route) {
-			continue
+	offset := filters.Offset
+
+	if cursor := filters.Cursor; cursor != nil {
+		if cursor.Filters != filters.Fingerprint() {
+			return models.RoutesResult{}, apperrors.New(apperrors.Validation, "cursor was issued for different filters",
+				apperrors.FieldError{
+					Field:   "cursor",
+					Message: "must be used with the filters of the request it came from",
+				})
 		}
 
-		if filters.Offset > 0 && skipped < filters.Offset {
-			skipped++
+		var ok bool
 
-			continue
+		snap, ok = p.stores.lookup(cursor.Snapshot, cfg.Aggregator)
+		if !ok {
+			return models.RoutesResult{}, apperrors.New(apperrors.Gone,
+				"the route snapshot referenced by the cursor has expired, restart paging without a cursor")
 		}
 
-		filtered = append(filtered, route)
+		offset = cursor.Position
 	}
 
-	return filtered
+	matches := snap.store.Query(filters)
+
+	return models.RoutesResult{
+		Routes:    matches.Slice(offset, limit),
+		Total:     matches.Len(),
+		Offset:    offset,
+		Snapshot:  snap.version,
+		Providers: reports,
+	}, nil
+}
+
+// providerRoutes is the answer of a provider, as cached.
+type providerRoutes struct {
+	routes []models.Route
+	// digest identifies the content of routes, so that a refresh returning
+	// the same routes does not replace the route snapshot.
+	digest [sha256.Size]byte
 }
 
-func (p provider) matchedFilters(filters models.RouteFilters, route models.Route) bool {
-	if filters.Airline != "" && route.Airline != filters.Airline {
-		return false
+func newProviderRoutes(routes []models.Route) (providerRoutes, error) {
+	data, err := json.Marshal(routes)
+	if err != nil {
+		return providerRoutes{}, fmt.Errorf("failed to hash routes: %w", err)
 	}
 
-	if filters.SourceAirport != "" && route.SourceAirport != filters.SourceAirport {
-		return false
+	return providerRoutes{routes: routes, digest: sha256.Sum256(data)}, nil
+}
+
+// routesFrom returns the cached routes of a provider, loading them on a miss.
+// The stale flag is set when the routes are past the provider cache TTL.
+func (p provider) routesFrom(ctx context.Context, entry Entry) (providerRoutes, bool, error) {
+	name := entry.Config.Name
+
+	item, err := p.cache.GetOrLoad(ctx, cacheKey(entry), cacheTTL(entry), routesLoader(entry))
+	if err != nil {
+		return providerRoutes{}, false, fmt.Errorf("error fetching routes from cache or %s: %w", name, err)
 	}
 
-	if filters.DestinationAirport != "" && route.DestinationAirport != filters.DestinationAirport {
-		return false
+	if routes, ok := item.Value.(providerRoutes); ok {
+		return routes, item.Stale, nil
 	}
 
-	if filters.MaxStops != nil && route.Stops > *filters.MaxStops {
-		return false
+	return providerRoutes{}, false, fmt.Errorf("unexpected data type from cache for %s routes", name)
+}
+
+func cacheKey(entry Entry) string {
+	return entry.Config.Name + "_routes"
+}
+
+func cacheTTL(entry Entry) cache.TTL {
+	return cache.TTL{
+		Fresh:    entry.Config.CacheTTL,
+		MaxStale: entry.Config.CacheMaxStale,
 	}
+}
+
+func routesLoader(entry Entry) func(ctx context.Context) (interface{}, error) {
+	return func(ctx context.Context) (interface{}, error) {
+		routes, err := entry.Source.FetchRoutes(ctx)
+		if err != nil {
+			return nil, err
+		}
 
-	return true
+		return newProviderRoutes(routes)
+	}
 }
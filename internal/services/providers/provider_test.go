@@ -1,11 +1,13 @@
 package providers
 
 import (
+	"context"
 	"net/http"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"flight-booking/internal/apperrors"
 	"flight-booking/internal/config"
 	"flight-booking/internal/models"
 	"flight-booking/internal/services/cache"
@@ -16,13 +18,39 @@ import (
 
 func createTestConfig(provider1URL, provider2URL string) config.Config {
 	return config.Config{
+		Cache: config.CacheConfig{
+			Enabled:         true,
+			DefaultTTL:      time.Minute,
+			CleanupInterval: time.Minute,
+		},
+		Aggregator: config.AggregatorConfig{
+			RequestTimeout: 30 * time.Second,
+			Merge: config.MergeConfig{
+				Enabled:   true,
+				Stops:     config.MergeFirst,
+				CodeShare: config.MergeFirst,
+				Equipment: config.MergeFirst,
+			},
+		},
 		Providers: config.ProvidersConfig{
-			Provider1BaseURL:  provider1URL,
-			Provider1Timeout:  30 * time.Second,
-			Provider1CacheTTL: 60 * time.Second,
-			Provider2BaseURL:  provider2URL,
-			Provider2Timeout:  30 * time.Second,
-			Provider2CacheTTL: 60 * time.Second,
+			List: []config.ProviderConfig{
+				{
+					Name:     "provider1",
+					BaseURL:  provider1URL,
+					Timeout:  30 * time.Second,
+					CacheTTL: 60 * time.Second,
+					Retries:  3,
+					Enabled:  true,
+				},
+				{
+					Name:     "provider2",
+					BaseURL:  provider2URL,
+					Timeout:  30 * time.Second,
+					CacheTTL: 60 * time.Second,
+					Retries:  3,
+					Enabled:  true,
+				},
+			},
 		},
 	}
 }
@@ -48,6 +76,15 @@ func createMockRoutes(provider string) []models.Route {
 	}
 }
 
+func cachedRoutes(t *testing.T, routes []models.Route) providerRoutes {
+	t.Helper()
+
+	value, err := newProviderRoutes(routes)
+	require.NoError(t, err)
+
+	return value
+}
+
 func TestProvider_GetRoutes_Success(t *testing.T) {
 	t.Parallel()
 
@@ -104,22 +141,24 @@ func TestProvider_GetRoutes_Success(t *testing.T) {
 
 	mockCache := cache.NewMockCache(t)
 	mockCache.EXPECT().
-		GetOrLoad("provider1_routes", mock.AnythingOfType("time.Duration"), mock.AnythingOfType("func() (interface {}, error)")).
-		Return(provider1Routes, nil)
+		GetOrLoad(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, provider1Routes)}, nil)
 
 	mockCache.EXPECT().
-		GetOrLoad("provider2_routes", mock.AnythingOfType("time.Duration"), mock.AnythingOfType("func() (interface {}, error)")).
-		Return(provider2Routes, nil)
+		GetOrLoad(mock.Anything, "provider2_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, provider2Routes)}, nil)
 
 	cfg := createTestConfig(server1.URL, server2.URL)
-	provider := New(cfg, mockCache)
+	provider := New(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), NewSnapshots())
 
 	ctx := t.Context()
 	filters := models.RouteFilters{}
-	routes, err := provider.GetRoutes(ctx, filters)
+	result, err := provider.GetRoutes(ctx, filters)
 
 	require.NoError(t, err)
-	assert.Len(t, routes, 4)
+	require.Len(t, result.Routes, 2, "Routes offered by both providers should be merged")
+	assert.Equal(t, []string{"provider1", "provider2"}, result.Routes[0].Providers)
+	assert.Equal(t, []string{"provider1", "provider2"}, result.Routes[1].Providers)
 }
 
 func TestProvider_GetRoutes_Provider2Fails(t *testing.T) {
@@ -138,14 +177,21 @@ func TestProvider_GetRoutes_Provider2Fails(t *testing.T) {
 	defer server2.Close()
 
 	cfg := createTestConfig(server1.URL, server2.URL)
-	provider := New(cfg, cache.New())
+	provider := New(config.NewLive(cfg), cache.New(cfg), NewRegistry(config.NewLive(cfg)), NewSnapshots())
 
 	ctx := t.Context()
 	filters := models.RouteFilters{}
-	routes, err := provider.GetRoutes(ctx, filters)
+	result, err := provider.GetRoutes(ctx, filters)
 
 	require.NoError(t, err)
-	assert.Len(t, routes, 1)
+	assert.Len(t, result.Routes, 1)
+
+	require.Len(t, result.Providers, 2)
+	assert.Equal(t, models.ProviderStatusOK, result.Providers[0].Status)
+	assert.Equal(t, 1, result.Providers[0].RouteCount)
+	assert.Equal(t, models.ProviderStatusFailed, result.Providers[1].Status)
+	require.Error(t, result.Providers[1].Error)
+	assert.Equal(t, []string{"provider2"}, result.FailedProviders())
 }
 
 func TestProvider_GetRoutes_BothProvidersFail(t *testing.T) {
@@ -162,14 +208,217 @@ func TestProvider_GetRoutes_BothProvidersFail(t *testing.T) {
 	defer server2.Close()
 
 	cfg := createTestConfig(server1.URL, server2.URL)
-	provider := New(cfg, cache.New())
+	provider := New(config.NewLive(cfg), cache.New(cfg), NewRegistry(config.NewLive(cfg)), NewSnapshots())
 
 	ctx := t.Context()
 	filters := models.RouteFilters{}
-	routes, err := provider.GetRoutes(ctx, filters)
+	result, err := provider.GetRoutes(ctx, filters)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Routes)
+}
+
+func TestProvider_GetRoutes_ReportsStaleRoutes(t *testing.T) {
+	t.Parallel()
+
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, createMockRoutes("provider1")), Stale: true}, nil)
+
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider2_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, createMockRoutes("provider2"))}, nil)
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+	provider := New(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), NewSnapshots())
+
+	result, err := provider.GetRoutes(t.Context(), models.RouteFilters{})
+
+	require.NoError(t, err)
+	assert.Len(t, result.Routes, 2)
+	assert.Equal(t, models.ProviderStatusStale, result.Providers[0].Status)
+	assert.Equal(t, models.ProviderStatusOK, result.Providers[1].Status)
+	assert.True(t, result.Stale())
+	assert.Empty(t, result.FailedProviders())
+}
+
+func TestProvider_GetRoutes_ReportsLateProvidersAsStale(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, createMockRoutes("provider1"))}, nil)
+
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider2_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, createMockRoutes("provider2"))}, nil).Once()
+
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider2_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		RunAndReturn(func(context.Context, string, cache.TTL, func(context.Context) (interface{}, error)) (cache.Item, error) {
+			<-release
+
+			return cache.Item{}, context.Canceled
+		})
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+	cfg.Aggregator.RequestTimeout = 100 * time.Millisecond
+
+	provider := New(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), NewSnapshots())
+
+	first, err := provider.GetRoutes(t.Context(), models.RouteFilters{})
+	require.NoError(t, err)
+
+	late, err := provider.GetRoutes(t.Context(), models.RouteFilters{})
+	require.NoError(t, err)
+
+	assert.Equal(t, first.Snapshot, late.Snapshot, "The earlier answer should be served in place of the late provider")
+	assert.Equal(t, []string{"provider1", "provider2"}, late.Routes[0].Providers)
+	assert.Equal(t, models.ProviderStatusStale, late.Providers[1].Status)
+	assert.Equal(t, 2, late.Providers[1].RouteCount)
+	require.ErrorIs(t, late.Providers[1].Error, context.DeadlineExceeded)
+	assert.Empty(t, late.FailedProviders())
+}
+
+func TestProvider_GetRoutes_ConfiguredProviders(t *testing.T) {
+	t.Parallel()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`[{"airline": "AA", "sourceAirport": "JFK", "destinationAirport": "LAX", "codeShare": "Y", "stops": 0, "provider": "provider3"}]`))
+	}))
+	defer server.Close()
+
+	disabled := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Disabled provider should not be called")
+	}))
+	defer disabled.Close()
+
+	cfg := createTestConfig(server.URL, server.URL)
+	cfg.Providers.List = append(cfg.Providers.List,
+		config.ProviderConfig{Name: "provider3", BaseURL: server.URL, Timeout: time.Second, CacheTTL: time.Minute, Enabled: true},
+		config.ProviderConfig{Name: "provider4", BaseURL: disabled.URL, Timeout: time.Second, CacheTTL: time.Minute},
+	)
+
+	provider := New(config.NewLive(cfg), cache.New(cfg), NewRegistry(config.NewLive(cfg)), NewSnapshots())
+
+	result, err := provider.GetRoutes(t.Context(), models.RouteFilters{})
+
+	require.NoError(t, err)
+	require.Len(t, result.Routes, 1)
+	assert.Equal(t, []string{"provider1", "provider2", "provider3"}, result.Routes[0].Providers)
+
+	require.Len(t, result.Providers, 4)
+	assert.Equal(t, models.ProviderStatusOK, result.Providers[2].Status)
+	assert.Equal(t, models.ProviderStatusSkipped, result.Providers[3].Status)
+	assert.Empty(t, result.FailedProviders())
+}
+
+func createDelayedServer(delay time.Duration, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-time.After(delay):
+		case <-r.Context().Done():
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(body))
+	}))
+}
+
+func createPassThroughCache(t *testing.T) *cache.MockCache {
+	t.Helper()
+
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		RunAndReturn(func(ctx context.Context, _ string, _ cache.TTL, loader func(context.Context) (interface{}, error)) (cache.Item, error) {
+			value, err := loader(ctx)
+
+			return cache.Item{Value: value}, err
+		})
+
+	return mockCache
+}
+
+func TestProvider_GetRoutes_SlowProviderDoesNotStallFastOne(t *testing.T) {
+	t.Parallel()
+
+	fast := createDelayedServer(0, `[{"airline": "AA", "sourceAirport": "JFK", "destinationAirport": "LAX", "codeShare": "Y", "stops": 0, "provider": "provider1"}]`)
+	defer fast.Close()
+
+	slow := createDelayedServer(5*time.Second, `[{"airline": "UA", "sourceAirport": "JFK", "destinationAirport": "SFO", "codeShare": "N", "stops": 1, "provider": "provider2"}]`)
+	defer slow.Close()
+
+	cfg := createTestConfig(fast.URL, slow.URL)
+	cfg.Aggregator.RequestTimeout = 300 * time.Millisecond
+
+	provider := New(config.NewLive(cfg), createPassThroughCache(t), NewRegistry(config.NewLive(cfg)), NewSnapshots())
+
+	start := time.Now()
+	result, err := provider.GetRoutes(t.Context(), models.RouteFilters{})
+	elapsed := time.Since(start)
 
 	require.NoError(t, err)
-	assert.Empty(t, routes)
+	require.Len(t, result.Routes, 1)
+	assert.Equal(t, "provider1", result.Routes[0].Provider)
+	assert.Less(t, elapsed, 2*time.Second, "Slow provider should be cut off by the request timeout")
+	assert.Equal(t, []string{"provider2"}, result.FailedProviders())
+}
+
+func TestProvider_GetRoutes_QueriesProvidersConcurrently(t *testing.T) {
+	t.Parallel()
+
+	delay := 500 * time.Millisecond
+
+	server1 := createDelayedServer(delay, `[{"airline": "AA", "sourceAirport": "JFK", "destinationAirport": "LAX", "codeShare": "Y", "stops": 0, "provider": "provider1"}]`)
+	defer server1.Close()
+
+	server2 := createDelayedServer(delay, `[{"airline": "UA", "sourceAirport": "JFK", "destinationAirport": "SFO", "codeShare": "N", "stops": 1, "provider": "provider2"}]`)
+	defer server2.Close()
+
+	cfg := createTestConfig(server1.URL, server2.URL)
+	provider := New(config.NewLive(cfg), createPassThroughCache(t), NewRegistry(config.NewLive(cfg)), NewSnapshots())
+
+	start := time.Now()
+	result, err := provider.GetRoutes(t.Context(), models.RouteFilters{})
+	elapsed := time.Since(start)
+
+	require.NoError(t, err)
+	require.Len(t, result.Routes, 2)
+	assert.Equal(t, "provider1", result.Routes[0].Provider, "Routes should be ordered by source, destination and airline")
+	assert.Equal(t, "provider2", result.Routes[1].Provider)
+	assert.Less(t, elapsed, 2*delay, "Providers should be queried in parallel")
+}
+
+func TestProvider_GetRoutes_AllProvidersTimeOut(t *testing.T) {
+	t.Parallel()
+
+	server1 := createDelayedServer(5*time.Second, `[]`)
+	defer server1.Close()
+
+	server2 := createDelayedServer(5*time.Second, `[]`)
+	defer server2.Close()
+
+	cfg := createTestConfig(server1.URL, server2.URL)
+	cfg.Aggregator.RequestTimeout = 100 * time.Millisecond
+
+	provider := New(config.NewLive(cfg), createPassThroughCache(t), NewRegistry(config.NewLive(cfg)), NewSnapshots())
+
+	start := time.Now()
+	result, err := provider.GetRoutes(t.Context(), models.RouteFilters{})
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Routes)
+	assert.Less(t, time.Since(start), 2*time.Second)
 }
 
 func TestProvider_CircuitBreakerFunctionality(t *testing.T) {
@@ -190,20 +439,20 @@ func TestProvider_CircuitBreakerFunctionality(t *testing.T) {
 	defer server2.Close()
 
 	cfg := createTestConfig(server1.URL, server2.URL)
-	provider := New(cfg, cache.New())
+	provider := New(config.NewLive(cfg), cache.New(cfg), NewRegistry(config.NewLive(cfg)), NewSnapshots())
 
 	ctx := t.Context()
 	filters := models.RouteFilters{}
 
-	routes, err := provider.GetRoutes(ctx, filters)
+	result, err := provider.GetRoutes(ctx, filters)
 
 	require.NoError(t, err)
-	assert.Empty(t, routes)
+	assert.Empty(t, result.Routes)
 
 	for range 5 {
-		routes, err = provider.GetRoutes(ctx, filters)
+		result, err = provider.GetRoutes(ctx, filters)
 		require.NoError(t, err)
-		assert.Empty(t, routes)
+		assert.Empty(t, result.Routes)
 	}
 
 	assert.Equal(t, 3, callCount, "Server should not be called after circuit breaker opens")
@@ -239,15 +488,15 @@ func TestProvider_RetryFunctionality(t *testing.T) {
 	}))
 
 	cfg := createTestConfig(server1.URL, server2.URL)
-	provider := New(cfg, cache.New())
+	provider := New(config.NewLive(cfg), cache.New(cfg), NewRegistry(config.NewLive(cfg)), NewSnapshots())
 
 	ctx := t.Context()
 	filters := models.RouteFilters{}
 
-	routes, err := provider.GetRoutes(ctx, filters)
+	result, err := provider.GetRoutes(ctx, filters)
 	require.NoError(t, err)
 
-	assert.Len(t, routes, 2)
+	assert.Len(t, result.Routes, 2)
 	assert.Equal(t, 2, callCountServer1)
 	assert.Equal(t, 2, callCountServer2)
 }
@@ -270,94 +519,131 @@ func TestProvider_CacheHit(t *testing.T) {
 
 	mockCache := cache.NewMockCache(t)
 	mockCache.EXPECT().
-		GetOrLoad("provider1_routes", mock.AnythingOfType("time.Duration"), mock.AnythingOfType("func() (interface {}, error)")).
-		Return(provider1Routes, nil)
+		GetOrLoad(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, provider1Routes)}, nil)
 
 	mockCache.EXPECT().
-		GetOrLoad("provider2_routes", mock.AnythingOfType("time.Duration"), mock.AnythingOfType("func() (interface {}, error)")).
-		Return(provider2Routes, nil)
+		GetOrLoad(mock.Anything, "provider2_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, provider2Routes)}, nil)
 
 	cfg := createTestConfig(server1.URL, server2.URL)
-	provider := New(cfg, mockCache)
+	provider := New(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), NewSnapshots())
 
 	ctx := t.Context()
 	filters := models.RouteFilters{}
-	routes, err := provider.GetRoutes(ctx, filters)
+	result, err := provider.GetRoutes(ctx, filters)
 
 	require.NoError(t, err)
-	assert.Len(t, routes, 4)
+	assert.Len(t, result.Routes, 2)
 }
 
-func TestProvider_ApplyFilters(t *testing.T) {
+func TestProvider_GetRoutes_PagesAreOrderedAndCounted(t *testing.T) {
 	t.Parallel()
 
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, []models.Route{
+			{Airline: "UA", SourceAirport: "SFO", DestinationAirport: "JFK"},
+			{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX"},
+		})}, nil)
+
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider2_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, []models.Route{
+			{Airline: "DL", SourceAirport: "ATL", DestinationAirport: "LAX"},
+			{Airline: "BA", SourceAirport: "JFK", DestinationAirport: "LAX"},
+		})}, nil)
+
 	cfg := createTestConfig("http://test1.com", "http://test2.com")
-	provider := New(cfg, cache.New()).(provider)
+	provider := New(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), NewSnapshots())
 
-	routes := []models.Route{
-		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", Stops: 0, Provider: "provider1"},
-		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", Stops: 1, Provider: "provider1"},
-		{Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", Stops: 0, Provider: "provider2"},
-		{Airline: "DL", SourceAirport: "JFK", DestinationAirport: "LAX", Stops: 2, Provider: "provider2"},
-	}
+	var airlines []string
 
-	tests := []struct {
-		name     string
-		filters  models.RouteFilters
-		expected int
-	}{
-		{
-			name:     "No filters",
-			filters:  models.RouteFilters{},
-			expected: 4,
-		},
-		{
-			name:     "Filter by airline",
-			filters:  models.RouteFilters{Airline: "AA"},
-			expected: 2,
-		},
-		{
-			name:     "Filter by source airport",
-			filters:  models.RouteFilters{SourceAirport: "JFK"},
-			expected: 3,
-		},
-		{
-			name:     "Filter by destination airport",
-			filters:  models.RouteFilters{DestinationAirport: "LAX"},
-			expected: 2,
-		},
-		{
-			name: "Filter by max stops",
-			filters: models.RouteFilters{MaxStops: func() *int {
-				i := 1
+	for offset := 0; offset < 4; offset += 3 {
+		result, err := provider.GetRoutes(t.Context(), models.RouteFilters{Limit: 3, Offset: offset})
+		require.NoError(t, err)
+		assert.Equal(t, 4, result.Total)
 
-				return &i
-			}()},
-			expected: 3,
-		},
-		{
-			name:     "Filter with limit",
-			filters:  models.RouteFilters{Limit: 2},
-			expected: 2,
-		},
-		{
-			name:     "Filter with offset",
-			filters:  models.RouteFilters{Offset: 1, Limit: 2},
-			expected: 2,
-		},
-		{
-			name:     "Combined filters",
-			filters:  models.RouteFilters{Airline: "AA", SourceAirport: "JFK", Limit: 1},
-			expected: 1,
-		},
+		for _, route := range result.Routes {
+			airlines = append(airlines, route.Airline)
+		}
 	}
 
-	for _, tt := range tests {
-		t.Run(tt.name, func(t *testing.T) {
-			t.Parallel()
+	assert.Equal(t, []string{"DL", "AA", "BA", "UA"}, airlines)
+}
 
-			result := provider.ApplyFilters(tt.filters, routes)
-			assert.Len(t, result, tt.expected)
-		})
+func TestProvider_GetRoutes_CursorPagesUseOneSnapshot(t *testing.T) {
+	t.Parallel()
+
+	before := []models.Route{
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX"},
+		{Airline: "BA", SourceAirport: "JFK", DestinationAirport: "LAX"},
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO"},
+	}
+	after := []models.Route{
+		{Airline: "DL", SourceAirport: "ATL", DestinationAirport: "LAX"},
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO"},
 	}
+
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, before)}, nil).Once()
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, after)}, nil)
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+	cfg.Providers.List[1].Enabled = false
+	cfg.Aggregator.SnapshotRetention = time.Minute
+	cfg.Aggregator.SnapshotMaxRetained = 8
+
+	provider := New(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), NewSnapshots())
+	filters := models.RouteFilters{SourceAirports: []string{"JFK"}, Limit: 2}
+
+	first, err := provider.GetRoutes(t.Context(), filters)
+	require.NoError(t, err)
+	require.Len(t, first.Routes, 2)
+
+	filters.Cursor = &models.Cursor{Snapshot: first.Snapshot, Position: 2, Filters: filters.Fingerprint()}
+
+	second, err := provider.GetRoutes(t.Context(), filters)
+	require.NoError(t, err)
+	assert.Equal(t, first.Snapshot, second.Snapshot)
+	assert.Equal(t, 3, second.Total)
+	assert.Equal(t, 2, second.Offset)
+	require.Len(t, second.Routes, 1)
+	assert.Equal(t, "UA", second.Routes[0].Airline)
+
+	filters.Cursor = nil
+
+	fresh, err := provider.GetRoutes(t.Context(), filters)
+	require.NoError(t, err)
+	assert.NotEqual(t, first.Snapshot, fresh.Snapshot)
+	assert.Equal(t, 1, fresh.Total)
+}
+
+func TestProvider_GetRoutes_InvalidCursors(t *testing.T) {
+	t.Parallel()
+
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		GetOrLoad(mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(cache.Item{Value: cachedRoutes(t, createMockRoutes("provider1"))}, nil)
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+	provider := New(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), NewSnapshots())
+
+	mismatched := models.RouteFilters{Airlines: []string{"AA"}}
+	mismatched.Cursor = &models.Cursor{Snapshot: 1, Filters: models.RouteFilters{}.Fingerprint()}
+
+	_, err := provider.GetRoutes(t.Context(), mismatched)
+	assert.Equal(t, apperrors.Validation, apperrors.As(err).Code)
+
+	evicted := models.RouteFilters{}
+	evicted.Cursor = &models.Cursor{Snapshot: 1, Filters: evicted.Fingerprint()}
+
+	_, err = provider.GetRoutes(t.Context(), evicted)
+	assert.Equal(t, apperrors.Gone, apperrors.As(err).Code)
 }
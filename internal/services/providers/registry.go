@@ -0,0 +1,129 @@
+package providers
+
+import (
+	"slices"
+	"sync"
+
+	"flight-booking/internal/config"
+)
+
+// Entry pairs a route source with the config it was declared with.
+type Entry struct {
+	Source RouteSource
+	Config config.ProviderConfig
+}
+
+// Registry holds the route sources declared in the providers config, in the
+// order they were declared, followed by the sources registered in code. It
+// follows configuration reloads.
+type Registry struct {
+	mu      sync.RWMutex
+	entries []Entry
+	// providers are the providers of the last applied config.
+	providers []config.ProviderConfig
+	// registered are the sources added with Register, in registration order.
+	registered []Entry
+}
+
+// NewRegistry creates an HTTP route source for every configured provider.
+func NewRegistry(live *config.Live) *Registry {
+	r := &Registry{}
+	r.Apply(live.Get().Providers.List)
+
+	live.Subscribe(func(config config.Config) {
+		r.Apply(config.Providers.List)
+	})
+
+	return r
+}
+
+// Register adds a route source that is not fetched over HTTP, keyed by its
+// name. A provider declared in the config with that name is served by the
+// source with the declared settings; otherwise config applies. Registered
+// sources survive configuration reloads. They should be registered before the
+// service starts, for the cache warmer to pick them up.
+func (r *Registry) Register(source RouteSource, config config.ProviderConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	config.Name = source.Name()
+
+	r.registered = slices.DeleteFunc(r.registered, func(entry Entry) bool {
+		return entry.Config.Name == config.Name
+	})
+	r.registered = append(r.registered, Entry{Source: source, Config: config})
+
+	r.apply(r.providers)
+}
+
+// Apply replaces the sources of the declared providers with the given
+// providers. Sources whose connection settings did not change are kept, so
+// their circuit breaker state survives the reload.
+func (r *Registry) Apply(providers []config.ProviderConfig) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.apply(providers)
+}
+
+func (r *Registry) apply(providers []config.ProviderConfig) {
+	entries := make([]Entry, 0, len(providers)+len(r.registered))
+
+	for _, provider := range providers {
+		if i := indexOf(r.registered, provider.Name); i >= 0 {
+			entries = append(entries, Entry{Source: r.registered[i].Source, Config: provider})
+
+			continue
+		}
+
+		i := indexOf(r.entries, provider.Name)
+		if i >= 0 && sameConnection(r.entries[i].Config, provider) {
+			entries = append(entries, Entry{Source: r.entries[i].Source, Config: provider})
+
+			continue
+		}
+
+		entries = append(entries, Entry{Source: NewHTTPSource(provider), Config: provider})
+	}
+
+	for _, entry := range r.registered {
+		if indexOf(entries, entry.Config.Name) < 0 {
+			entries = append(entries, entry)
+		}
+	}
+
+	r.entries = entries
+	r.providers = providers
+}
+
+// Entries returns the registered sources in declaration order.
+func (r *Registry) Entries() []Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return slices.Clone(r.entries)
+}
+
+// Lookup returns the registered source with the given name.
+func (r *Registry) Lookup(name string) (Entry, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := indexOf(r.entries, name)
+	if i < 0 {
+		return Entry{}, false
+	}
+
+	return r.entries[i], true
+}
+
+// indexOf returns the index of the entry with the given name, or -1.
+func indexOf(entries []Entry, name string) int {
+	return slices.IndexFunc(entries, func(entry Entry) bool {
+		return entry.Config.Name == name
+	})
+}
+
+func sameConnection(a, b config.ProviderConfig) bool {
+	return a.BaseURL == b.BaseURL && a.Timeout == b.Timeout && a.Retries == b.Retries
+}
@@ -0,0 +1,69 @@
+package providers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSource struct {
+	name   string
+	routes []models.Route
+}
+
+func (s staticSource) Name() string {
+	return s.name
+}
+
+func (s staticSource) FetchRoutes(context.Context) ([]models.Route, error) {
+	return s.routes, nil
+}
+
+func names(entries []Entry) []string {
+	result := make([]string, len(entries))
+	for i, entry := range entries {
+		result[i] = entry.Config.Name
+	}
+
+	return result
+}
+
+func TestRegistry_KeepsRegisteredSourcesAcrossReloads(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+	live := config.NewLive(cfg)
+	registry := NewRegistry(live)
+
+	static := staticSource{name: "static", routes: createMockRoutes("static")}
+	registry.Register(static, config.ProviderConfig{Name: "ignored", CacheTTL: time.Minute, Enabled: true})
+
+	entry, ok := registry.Lookup("static")
+	require.True(t, ok, "A registered source should be keyed by its name")
+	assert.Equal(t, static, entry.Source)
+	assert.Equal(t, time.Minute, entry.Config.CacheTTL)
+	assert.Equal(t, []string{"provider1", "provider2", "static"}, names(registry.Entries()))
+
+	replacement := staticSource{name: "provider2"}
+	registry.Register(replacement, config.ProviderConfig{})
+
+	entry, ok = registry.Lookup("provider2")
+	require.True(t, ok)
+	assert.Equal(t, replacement, entry.Source, "A registered source should serve the provider declared with its name")
+	assert.Equal(t, cfg.Providers.List[1], entry.Config, "The declared settings should apply")
+
+	reloaded := cfg
+	reloaded.Providers.List = cfg.Providers.List[:1]
+	live.Set(reloaded)
+
+	assert.Equal(t, []string{"provider1", "static", "provider2"}, names(registry.Entries()),
+		"Registered sources should survive a reload")
+
+	entry, _ = registry.Lookup("provider2")
+	assert.Equal(t, replacement, entry.Source)
+}
@@ -0,0 +1,59 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"resty.dev/v3"
+)
+
+// RouteSource is implemented by every upstream route provider.
+type RouteSource interface {
+	Name() string
+	FetchRoutes(ctx context.Context) ([]models.Route, error)
+}
+
+type httpSource struct {
+	name   string
+	client *resty.Client
+}
+
+// NewHTTPSource creates a route source that fetches the route list with a
+// GET request to the provider base URL.
+func NewHTTPSource(config config.ProviderConfig) RouteSource {
+	return &httpSource{
+		name: config.Name,
+		client: resty.New().
+			SetBaseURL(config.BaseURL).
+			SetTimeout(config.Timeout).
+			SetRetryCount(config.Retries).
+			SetCircuitBreaker(resty.NewCircuitBreaker()),
+	}
+}
+
+func (s *httpSource) Name() string {
+	return s.name
+}
+
+func (s *httpSource) FetchRoutes(ctx context.Context) ([]models.Route, error) {
+	var res []models.Route
+
+	resp, err := s.client.R().
+		SetContext(ctx).
+		SetResult(&res).
+		Get("")
+	if err != nil {
+		return nil, apperrors.Wrap(apperrors.UpstreamUnavailable, fmt.Errorf("%s request failed: %w", s.name, err))
+	}
+
+	if resp.StatusCode() != http.StatusOK {
+		return nil, apperrors.New(apperrors.UpstreamUnavailable,
+			fmt.Sprintf("%s request failed: %s", s.name, resp.String()))
+	}
+
+	return res, nil
+}
@@ -0,0 +1,199 @@
+package providers
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/routestore"
+)
+
+// snapshot is a version of the merged provider data.
+type snapshot struct {
+	version int64
+	store   *routestore.Store
+	// inputs are the provider answers the store was built from, in provider
+	// declaration order.
+	inputs     []storeInput
+	rules      config.MergeConfig
+	replacedAt time.Time
+}
+
+// Snapshots keeps the route store built from the latest provider data. The
+// store is only rebuilt when the routes of a provider or the merge rules
+// change, so a refresh returning the same routes keeps the snapshot and the
+// cursors into it.
+// Replaced snapshots are retained for a while for clients paging with cursors.
+type Snapshots struct {
+	mu       sync.Mutex
+	current  *snapshot
+	replaced []*snapshot
+	// version is the latest version handed out.
+	version int64
+}
+
+// NewSnapshots creates an empty set of snapshots.
+func NewSnapshots() *Snapshots {
+	return &Snapshots{}
+}
+
+// Current returns the route store of the latest provider data without
+// querying providers, or nil if no data has been loaded yet. It lacks the
+// routes of providers that have not answered yet.
+func (c *Snapshots) Current() *routestore.Store {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.current == nil {
+		return nil
+	}
+
+	return c.current.store
+}
+
+// storeInput is what a provider contributed to a snapshot.
+type storeInput struct {
+	name   string
+	routes providerRoutes
+	// ok is set when routes are an answer of the provider, and unset when it
+	// failed or had not answered yet.
+	ok bool
+}
+
+// sameInputs reports whether a and b hold the same routes, comparing them by
+// the digest computed when they were loaded.
+func sameInputs(a, b []storeInput) bool {
+	return slices.EqualFunc(a, b, func(x, y storeInput) bool {
+		return x.name == y.name && x.ok == y.ok && x.routes.digest == y.routes.digest
+	})
+}
+
+// get returns the snapshot of the routes of inputs, building it unless the
+// current snapshot holds the same routes.
+//
+// Providers marked as missing did not answer in time. What they contributed
+// to the current snapshot stands in for them, so that a slow provider does
+// not replace the current snapshot with a partial one, and a provider that
+// has not answered yet does not rebuild it on every request.
+func (c *Snapshots) get(cfg config.AggregatorConfig, inputs []storeInput, missing []bool) *snapshot {
+	rules := cfg.Merge
+
+	c.mu.Lock()
+
+	c.evict(time.Now(), cfg)
+
+	current := c.current
+
+	if current != nil {
+		for i, input := range inputs {
+			if !missing[i] {
+				continue
+			}
+
+			previous := slices.IndexFunc(current.inputs, func(prev storeInput) bool { return prev.name == input.name })
+			if previous >= 0 {
+				inputs[i] = current.inputs[previous]
+			}
+		}
+
+		if current.rules == rules && sameInputs(current.inputs, inputs) {
+			c.mu.Unlock()
+
+			return current
+		}
+	}
+
+	c.mu.Unlock()
+
+	// The store is built without holding the lock, as merging, sorting and
+	// indexing every route takes a while.
+	names := make([]string, len(inputs))
+	sources := make([][]models.Route, len(inputs))
+
+	for i, input := range inputs {
+		names[i] = input.name
+		sources[i] = input.routes.routes
+	}
+
+	merged := mergeRoutes(rules, names, sources)
+	sortRoutes(merged)
+	store := routestore.New(merged)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	c.version = max(now.UnixNano(), c.version+1)
+	next := &snapshot{version: c.version, store: store, inputs: inputs, rules: rules}
+
+	// A snapshot built concurrently from data at least as recent may have
+	// become current in the meantime; it is not replaced.
+	if c.current != current {
+		next.replacedAt = now
+		c.replaced = append(c.replaced, next)
+		c.evict(now, cfg)
+
+		return next
+	}
+
+	if current != nil {
+		current.replacedAt = now
+		c.replaced = append(c.replaced, current)
+	}
+
+	c.current = next
+	c.evict(now, cfg)
+
+	return next
+}
+
+// lookup returns the snapshot with the given version unless it has been
+// evicted.
+func (c *Snapshots) lookup(version int64, cfg config.AggregatorConfig) (*snapshot, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.evict(time.Now(), cfg)
+
+	if c.current != nil && c.current.version == version {
+		return c.current, true
+	}
+
+	for _, snap := range c.replaced {
+		if snap.version == version {
+			return snap, true
+		}
+	}
+
+	return nil, false
+}
+
+// evict drops the replaced snapshots older than the retention and, beyond the
+// maximum number retained, the oldest ones.
+func (c *Snapshots) evict(now time.Time, cfg config.AggregatorConfig) {
+	c.replaced = slices.DeleteFunc(c.replaced, func(snap *snapshot) bool {
+		return now.Sub(snap.replacedAt) > cfg.SnapshotRetention
+	})
+
+	if excess := len(c.replaced) - max(cfg.SnapshotMaxRetained, 0); excess > 0 {
+		clear(c.replaced[:excess])
+		c.replaced = c.replaced[excess:]
+	}
+}
+
+// sortRoutes orders routes by source, destination and airline, so that pages
+// stay stable however providers order their responses. Routes with the same
+// identity keep the provider declaration order.
+func sortRoutes(routes []models.Route) {
+	slices.SortStableFunc(routes, func(a, b models.Route) int {
+		return cmp.Or(
+			strings.Compare(a.SourceAirport, b.SourceAirport),
+			strings.Compare(a.DestinationAirport, b.DestinationAirport),
+			strings.Compare(a.Airline, b.Airline),
+		)
+	})
+}
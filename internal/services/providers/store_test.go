@@ -0,0 +1,146 @@
+package providers
+
+import (
+	"testing"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// answers returns the inputs of providers answering with routes, or failing
+// for nil routes.
+func answers(t *testing.T, names []string, routes ...[]models.Route) []storeInput {
+	t.Helper()
+
+	inputs := make([]storeInput, len(names))
+	for i, name := range names {
+		inputs[i] = storeInput{name: name, ok: routes[i] != nil}
+		if routes[i] != nil {
+			inputs[i].routes = cachedRoutes(t, routes[i])
+		}
+	}
+
+	return inputs
+}
+
+// revision returns the routes of a provider with the first one changed by n.
+func revision(provider string, n int) []models.Route {
+	routes := createMockRoutes(provider)
+	routes[0].Stops = n
+
+	return routes
+}
+
+func TestStoreCache_RebuildsOnlyWhenDataChanges(t *testing.T) {
+	t.Parallel()
+
+	var stores Snapshots
+
+	names := []string{"provider1", "provider2"}
+	inTime := make([]bool, len(names))
+	cfg := config.AggregatorConfig{SnapshotRetention: time.Minute, SnapshotMaxRetained: 8, Merge: config.MergeConfig{Enabled: true}}
+	provider1 := createMockRoutes("provider1")
+	provider2 := createMockRoutes("provider2")
+
+	first := stores.get(cfg, answers(t, names, provider1, provider2), inTime)
+	assert.Same(t, first, stores.get(cfg, answers(t, names, provider1, provider2), inTime))
+	assert.Equal(t, 2, first.store.Len())
+
+	unchanged := stores.get(cfg, answers(t, names, provider1, createMockRoutes("provider2")), inTime)
+	assert.Same(t, first, unchanged, "A refresh returning the same routes should keep the snapshot")
+
+	refreshed := stores.get(cfg, answers(t, names, provider1, revision("provider2", 1)), inTime)
+	assert.NotSame(t, first, refreshed, "A refreshed provider should rebuild the store")
+	assert.Greater(t, refreshed.version, first.version)
+
+	cfg.Merge = config.MergeConfig{}
+
+	unmerged := stores.get(cfg, answers(t, names, provider1, provider2), inTime)
+	assert.Equal(t, 4, unmerged.store.Len(), "Changed merge rules should rebuild the store")
+
+	failed := stores.get(cfg, answers(t, names, provider1, nil), inTime)
+	assert.Equal(t, 2, failed.store.Len())
+}
+
+func TestStoreCache_KeepsRoutesOfMissingProviders(t *testing.T) {
+	t.Parallel()
+
+	var stores Snapshots
+
+	names := []string{"provider1", "provider2"}
+	cfg := config.AggregatorConfig{SnapshotRetention: time.Minute, SnapshotMaxRetained: 8}
+	provider1 := createMockRoutes("provider1")
+	provider2 := createMockRoutes("provider2")
+
+	partial := stores.get(cfg, answers(t, names, provider1, nil), []bool{false, true})
+	assert.Equal(t, 2, partial.store.Len())
+	assert.Same(t, partial.store, stores.Current(), "A snapshot lacking a provider that never answered should become current")
+
+	still := stores.get(cfg, answers(t, names, provider1, nil), []bool{false, true})
+	assert.Same(t, partial, still, "A provider that keeps missing the deadline should not rebuild the store")
+	assert.False(t, still.inputs[1].ok)
+
+	complete := stores.get(cfg, answers(t, names, provider1, provider2), []bool{false, false})
+	assert.Same(t, complete.store, stores.Current())
+
+	slow := stores.get(cfg, answers(t, names, provider1, nil), []bool{false, true})
+	assert.Same(t, complete, slow, "A provider missing the deadline should not rebuild the store")
+	assert.Equal(t, 4, slow.store.Len())
+	assert.True(t, slow.inputs[1].ok, "The earlier answer should stand in for the missing provider")
+
+	_, ok := stores.lookup(partial.version, cfg)
+	assert.True(t, ok, "The replaced partial snapshot should be retained for cursors")
+}
+
+func TestStoreCache_RetainsReplacedSnapshots(t *testing.T) {
+	t.Parallel()
+
+	var stores Snapshots
+
+	names := []string{"provider1"}
+	inTime := make([]bool, len(names))
+	cfg := config.AggregatorConfig{SnapshotRetention: time.Minute, SnapshotMaxRetained: 8, Merge: config.MergeConfig{Enabled: true}}
+
+	first := stores.get(cfg, answers(t, names, revision("provider1", 0)), inTime)
+	second := stores.get(cfg, answers(t, names, revision("provider1", 1)), inTime)
+
+	found, ok := stores.lookup(first.version, cfg)
+	require.True(t, ok)
+	assert.Same(t, first, found)
+
+	found, ok = stores.lookup(second.version, cfg)
+	require.True(t, ok)
+	assert.Same(t, second, found)
+
+	expired := cfg
+	expired.SnapshotRetention = 0
+
+	_, ok = stores.lookup(first.version, expired)
+	assert.False(t, ok, "Snapshots replaced longer ago than the retention should be evicted")
+
+	_, ok = stores.lookup(second.version, expired)
+	assert.True(t, ok, "The current snapshot is never evicted")
+}
+
+func TestStoreCache_CapsReplacedSnapshots(t *testing.T) {
+	t.Parallel()
+
+	var stores Snapshots
+
+	names := []string{"provider1"}
+	inTime := make([]bool, len(names))
+	cfg := config.AggregatorConfig{SnapshotRetention: time.Hour, SnapshotMaxRetained: 2}
+
+	versions := make([]int64, 5)
+	for i := range versions {
+		versions[i] = stores.get(cfg, answers(t, names, revision("provider1", i)), inTime).version
+	}
+
+	for i, version := range versions {
+		_, ok := stores.lookup(version, cfg)
+		assert.Equal(t, i >= 2, ok, "Only the current and the two latest replaced snapshots should be kept, got %d", i)
+	}
+}
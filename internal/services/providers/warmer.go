@@ -0,0 +1,252 @@
+package providers
+
+import (
+	"context"
+	"math/rand/v2"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/services/cache"
+	"flight-booking/internal/services/logger"
+	"go.uber.org/fx"
+)
+
+const minRefreshInterval = time.Second
+
+// Warmer prefetches provider routes into the cache on startup and refreshes
+// them on a jittered schedule before their cache TTL passes, so requests do
+// not pay the upstream latency. Providers enabled or added by a configuration
+// reload are picked up, and refreshing stops for disabled ones.
+type Warmer struct {
+	config   *config.Live
+	cache    cache.Cache
+	registry *Registry
+	logger   logger.Logger
+
+	warm atomic.Bool
+
+	mu      sync.Mutex
+	ctx     context.Context //nolint:containedctx // cancelled by the fx stop hook
+	cancel  context.CancelFunc
+	running map[string]context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+func NewWarmer(
+	live *config.Live,
+	cache cache.Cache,
+	registry *Registry,
+	logger logger.Logger,
+	lc fx.Lifecycle,
+) *Warmer {
+	w := &Warmer{
+		config:   live,
+		cache:    cache,
+		registry: registry,
+		logger:   logger.With("component", "cache_warmer"),
+		running:  make(map[string]context.CancelFunc),
+	}
+
+	if !live.Get().Warmer.Enabled {
+		w.warm.Store(true)
+
+		return w
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: w.start,
+		OnStop:  w.stop,
+	})
+
+	live.Subscribe(func(config.Config) {
+		w.reconcile()
+	})
+
+	return w
+}
+
+// Warm reports whether every enabled provider has been fetched successfully at
+// least once.
+func (w *Warmer) Warm() bool {
+	return w.warm.Load()
+}
+
+func (w *Warmer) start(_ context.Context) error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	w.ctx, w.cancel = context.WithCancel(context.Background())
+
+	var initial sync.WaitGroup
+
+	for _, entry := range w.registry.Entries() {
+		if entry.Config.Enabled {
+			w.run(entry.Config.Name, &initial)
+		}
+	}
+
+	go func() {
+		initial.Wait()
+		w.warm.Store(true)
+		w.logger.Info("initial provider fetch finished")
+	}()
+
+	return nil
+}
+
+func (w *Warmer) stop(ctx context.Context) error {
+	w.mu.Lock()
+	w.cancel()
+	w.mu.Unlock()
+
+	done := make(chan struct{})
+
+	go func() {
+		w.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		w.logger.Warn("cache warmer did not stop in time")
+
+		return nil
+	}
+}
+
+// reconcile starts refreshing newly enabled providers and stops refreshing
+// the ones that were disabled or removed.
+func (w *Warmer) reconcile() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.ctx == nil || w.ctx.Err() != nil {
+		return
+	}
+
+	enabled := make(map[string]bool)
+
+	for _, entry := range w.registry.Entries() {
+		if !entry.Config.Enabled {
+			continue
+		}
+
+		enabled[entry.Config.Name] = true
+
+		if _, ok := w.running[entry.Config.Name]; !ok {
+			w.run(entry.Config.Name, nil)
+		}
+	}
+
+	for name, cancel := range w.running {
+		if !enabled[name] {
+			cancel()
+			delete(w.running, name)
+		}
+	}
+}
+
+// run starts the refresh loop of a provider. It must be called with w.mu held.
+func (w *Warmer) run(name string, initial *sync.WaitGroup) {
+	ctx, cancel := context.WithCancel(w.ctx)
+	w.running[name] = cancel
+
+	if initial != nil {
+		initial.Add(1)
+	}
+
+	w.wg.Add(1)
+
+	go func() {
+		defer w.wg.Done()
+
+		w.schedule(ctx, name, initial)
+	}()
+}
+
+// schedule refreshes a provider until ctx is cancelled. initial, if set, is
+// marked done once the provider has been fetched successfully, or when the
+// loop ends before, as a disabled provider no longer holds up readiness.
+// Until then failed fetches are retried with a doubling delay instead of
+// waiting for the next regular refresh.
+func (w *Warmer) schedule(ctx context.Context, name string, initial *sync.WaitGroup) {
+	fetched := false
+	retry := minRefreshInterval
+
+	defer func() {
+		if initial != nil && !fetched {
+			initial.Done()
+		}
+	}()
+
+	for {
+		if w.refresh(ctx, name) && !fetched {
+			fetched = true
+
+			if initial != nil {
+				initial.Done()
+			}
+		}
+
+		entry, ok := w.registry.Lookup(name)
+		if !ok {
+			return
+		}
+
+		delay := w.interval(entry)
+		if !fetched {
+			delay = min(retry, delay)
+			retry *= 2
+		}
+
+		timer := time.NewTimer(delay)
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+
+			return
+		case <-timer.C:
+		}
+	}
+}
+
+// refresh fetches the routes of a provider into the cache and reports whether
+// it succeeded.
+func (w *Warmer) refresh(ctx context.Context, name string) bool {
+	entry, ok := w.registry.Lookup(name)
+	if !ok {
+		return false
+	}
+
+	start := time.Now()
+
+	err := w.cache.Refresh(ctx, cacheKey(entry), cacheTTL(entry), routesLoader(entry))
+	if err != nil {
+		w.logger.Error("failed to refresh provider routes", "provider", name, "error", err)
+
+		return false
+	}
+
+	w.logger.Debug("refreshed provider routes", "provider", name, "duration", time.Since(start))
+
+	return true
+}
+
+// interval returns the delay until the next refresh of entry: its cache TTL
+// minus the configured lead time and a random jitter, so providers sharing a
+// TTL are not all refreshed at the same instant.
+func (w *Warmer) interval(entry Entry) time.Duration {
+	cfg := w.config.Get().Warmer
+	interval := entry.Config.CacheTTL - cfg.RefreshBefore
+
+	if cfg.Jitter > 0 {
+		interval -= rand.N(cfg.Jitter) //nolint:gosec // jitter does not need a secure source
+	}
+
+	return max(interval, minRefreshInterval)
+}
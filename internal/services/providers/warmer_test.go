@@ -0,0 +1,94 @@
+package providers
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/services/cache"
+	"flight-booking/internal/services/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+)
+
+func TestWarmer_PrefetchesAndRefreshesProviders(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+	cfg.Providers.List[0].CacheTTL = 50 * time.Millisecond
+	cfg.Providers.List[1].Enabled = false
+	cfg.Warmer = config.WarmerConfig{Enabled: true, RefreshBefore: 10 * time.Millisecond}
+
+	var refreshes atomic.Int32
+
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		Refresh(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		RunAndReturn(func(context.Context, string, cache.TTL, func(context.Context) (interface{}, error)) error {
+			refreshes.Add(1)
+
+			return nil
+		})
+
+	lc := fxtest.NewLifecycle(t)
+	warmer := NewWarmer(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), logger.Context(t.Context()), lc)
+
+	assert.False(t, warmer.Warm())
+
+	lc.RequireStart()
+
+	require.Eventually(t, warmer.Warm, time.Second, 5*time.Millisecond)
+	require.Eventually(t, func() bool { return refreshes.Load() >= 3 }, 5*time.Second, 10*time.Millisecond,
+		"Provider should be refreshed before its TTL passes")
+
+	lc.RequireStop()
+
+	stopped := refreshes.Load()
+	time.Sleep(100 * time.Millisecond)
+	assert.Equal(t, stopped, refreshes.Load(), "No refreshes should happen after stop")
+}
+
+func TestWarmer_OnlySuccessfulFetchesWarm(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+	cfg.Providers.List[1].Enabled = false
+	cfg.Warmer = config.WarmerConfig{Enabled: true}
+
+	mockCache := cache.NewMockCache(t)
+	mockCache.EXPECT().
+		Refresh(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(errors.New("provider down")).Once()
+
+	mockCache.EXPECT().
+		Refresh(mock.Anything, "provider1_routes", mock.AnythingOfType("cache.TTL"), mock.AnythingOfType("func(context.Context) (interface {}, error)")).
+		Return(nil)
+
+	lc := fxtest.NewLifecycle(t)
+	warmer := NewWarmer(config.NewLive(cfg), mockCache, NewRegistry(config.NewLive(cfg)), logger.Context(t.Context()), lc)
+
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	assert.Never(t, warmer.Warm, minRefreshInterval/2, 5*time.Millisecond, "A failed fetch should not count as warm")
+	require.Eventually(t, warmer.Warm, 3*minRefreshInterval, 5*time.Millisecond,
+		"A failed first fetch should be retried before the next regular refresh")
+}
+
+func TestWarmer_Disabled(t *testing.T) {
+	t.Parallel()
+
+	cfg := createTestConfig("http://test1.com", "http://test2.com")
+
+	lc := fxtest.NewLifecycle(t)
+	warmer := NewWarmer(config.NewLive(cfg), cache.NewMockCache(t), NewRegistry(config.NewLive(cfg)), logger.Context(t.Context()), lc)
+
+	lc.RequireStart()
+	assert.True(t, warmer.Warm())
+	lc.RequireStop()
+}
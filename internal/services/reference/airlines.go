@@ -0,0 +1,149 @@
+package reference
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+)
+
+// airlinesDataset is a selection of major airlines in the OpenFlights
+// airlines.dat format.
+//
+//go:embed airlines.dat
+var airlinesDataset []byte
+
+// Airlines is an immutable airline catalog indexed by IATA code.
+type Airlines struct {
+	airlines []models.Airline
+	byIATA   map[string]int
+}
+
+// NewAirlines loads the catalog from reference.airlines_file, or from the
+// embedded dataset when no file is configured.
+func NewAirlines(cfg config.Config) (*Airlines, error) {
+	data, err := readDataset(cfg.Reference.AirlinesFile, airlinesDataset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read airlines file: %w", err)
+	}
+
+	airlines, err := ParseAirlines(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load airlines: %w", err)
+	}
+
+	return airlines, nil
+}
+
+// ParseAirlines reads airlines in the OpenFlights airlines.dat format.
+// Airlines without an IATA code are skipped. IATA codes are reassigned once an
+// airline ceases operations, so of airlines sharing a code the first active
+// one is kept, or the first one if none is active.
+func ParseAirlines(r io.Reader) (*Airlines, error) {
+	a := &Airlines{byIATA: make(map[string]int)}
+
+	err := readRecords(r, 8, func(record []string) error {
+		airline, err := parseAirline(record)
+		if err != nil {
+			return err
+		}
+
+		if airline.IATA == "" || airline.IATA == "-" {
+			return nil
+		}
+
+		if i, ok := a.byIATA[airline.IATA]; ok {
+			if !a.airlines[i].Active && airline.Active {
+				a.airlines[i] = airline
+			}
+
+			return nil
+		}
+
+		a.byIATA[airline.IATA] = len(a.airlines)
+		a.airlines = append(a.airlines, airline)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(a.airlines, func(x, y models.Airline) int { return strings.Compare(x.IATA, y.IATA) })
+
+	for i, airline := range a.airlines {
+		a.byIATA[airline.IATA] = i
+	}
+
+	return a, nil
+}
+
+func parseAirline(record []string) (models.Airline, error) {
+	field := func(i int) string { return openFlightsField(record, i) }
+
+	var active bool
+
+	switch strings.ToUpper(field(7)) {
+	case "Y":
+		active = true
+	case "N":
+	default:
+		return models.Airline{}, fmt.Errorf("invalid active flag %q", record[7])
+	}
+
+	airline := models.Airline{
+		IATA:     field(3),
+		ICAO:     field(4),
+		Name:     field(1),
+		Callsign: field(5),
+		Country:  field(6),
+		Active:   active,
+	}
+
+	// Alliance membership is kept by code, which a defunct airline may share
+	// with a current member.
+	if active {
+		airline.Alliance = AllianceOf(airline.IATA)
+	}
+
+	return airline, nil
+}
+
+// Len returns the number of airlines in the catalog.
+func (a *Airlines) Len() int {
+	return len(a.airlines)
+}
+
+// Get returns the airline with the given IATA code.
+func (a *Airlines) Get(code string) (models.Airline, bool) {
+	i, ok := a.byIATA[code]
+	if !ok {
+		return models.Airline{}, false
+	}
+
+	return a.airlines[i], true
+}
+
+// List returns the airlines matching filters, ordered by IATA code.
+func (a *Airlines) List(filters models.AirlineFilters) []models.Airline {
+	result := make([]models.Airline, 0, len(a.airlines))
+
+	for _, airline := range a.airlines {
+		if filters.Active != nil && airline.Active != *filters.Active {
+			continue
+		}
+
+		if filters.Alliance != "" && airline.Alliance != filters.Alliance {
+			continue
+		}
+
+		result = append(result, airline)
+	}
+
+	return result
+}
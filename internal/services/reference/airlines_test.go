@@ -0,0 +1,142 @@
+package reference
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAirlines = `-1,"Unknown",\N,"-","N/A",\N,\N,"Y"
+1,"British Airways",\N,"BA","BAW","SPEEDBIRD","United Kingdom","Y"
+2,"Alitalia",\N,"AZ","AZA","ALITALIA","Italy","N"
+3,"ITA Airways",\N,"AZ","ITY","ITARROW","Italy","Y"
+4,"Private Charter",\N,\N,"PVT",\N,"United States","Y"
+5,"Lufthansa",\N,"LH","DLH","LUFTHANSA","Germany","Y"
+6,"Lufthansa Cargo",\N,"LH","GEC","LUFTHANSA CARGO","Germany","Y"
+7,"United Airlines",\N,"UA","UAL","UNITED","United States","Y"
+8,"Air Berlin",\N,"AB","BER","AIR BERLIN","Germany","N"
+`
+
+func TestParseAirlines(t *testing.T) {
+	t.Parallel()
+
+	airlines, err := ParseAirlines(strings.NewReader(testAirlines))
+	require.NoError(t, err)
+
+	assert.Equal(t, 5, airlines.Len(), "Airlines without IATA code and duplicates should be skipped")
+
+	ba, ok := airlines.Get("BA")
+	require.True(t, ok)
+	assert.Equal(t, models.Airline{
+		IATA: "BA", ICAO: "BAW", Name: "British Airways", Callsign: "SPEEDBIRD", Country: "United Kingdom",
+		Active: true, Alliance: "oneworld",
+	}, ba)
+
+	az, ok := airlines.Get("AZ")
+	require.True(t, ok)
+	assert.Equal(t, "ITA Airways", az.Name, "The active airline should replace a defunct one with the same code")
+
+	lh, ok := airlines.Get("LH")
+	require.True(t, ok)
+	assert.Equal(t, "Lufthansa", lh.Name, "The first active airline should be kept")
+
+	_, ok = airlines.Get("ZZ")
+	assert.False(t, ok)
+}
+
+func TestParseAirlines_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		dataset string
+		wantErr string
+	}{
+		{
+			name:    "too few fields",
+			dataset: `1,"British Airways",\N,"BA"`,
+			wantErr: "line 1: expected at least 8 fields, got 4",
+		},
+		{
+			name: "invalid active flag",
+			dataset: `1,"British Airways",\N,"BA","BAW","SPEEDBIRD","United Kingdom","Y"
+2,"Lufthansa",\N,"LH","DLH","LUFTHANSA","Germany","maybe"`,
+			wantErr: `line 2: invalid active flag "maybe"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseAirlines(strings.NewReader(tt.dataset))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAirlines_List(t *testing.T) {
+	t.Parallel()
+
+	airlines, err := ParseAirlines(strings.NewReader(testAirlines))
+	require.NoError(t, err)
+
+	active, inactive := true, false
+
+	tests := []struct {
+		name    string
+		filters models.AirlineFilters
+		want    []string
+	}{
+		{name: "every airline", want: []string{"AB", "AZ", "BA", "LH", "UA"}},
+		{name: "active", filters: models.AirlineFilters{Active: &active}, want: []string{"AZ", "BA", "LH", "UA"}},
+		{name: "inactive", filters: models.AirlineFilters{Active: &inactive}, want: []string{"AB"}},
+		{name: "alliance", filters: models.AirlineFilters{Alliance: "star_alliance"}, want: []string{"LH", "UA"}},
+		{name: "no members", filters: models.AirlineFilters{Alliance: "skyteam"}, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := airlines.List(tt.filters)
+
+			codes := make([]string, len(got))
+			for i, airline := range got {
+				codes[i] = airline.IATA
+			}
+
+			assert.Equal(t, tt.want, codes)
+		})
+	}
+}
+
+func TestNewAirlines(t *testing.T) {
+	t.Parallel()
+
+	embedded, err := NewAirlines(config.Config{})
+	require.NoError(t, err)
+
+	// Every alliance member should be in the embedded catalog.
+	for alliance, members := range alliances {
+		for _, code := range members {
+			airline, ok := embedded.Get(code)
+			if assert.True(t, ok, "%s member %s is missing", alliance, code) {
+				assert.Equal(t, alliance, airline.Alliance)
+			}
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), "airlines.dat")
+	require.NoError(t, os.WriteFile(path, []byte(testAirlines), 0o600))
+
+	fromFile, err := NewAirlines(config.Config{Reference: config.ReferenceConfig{AirlinesFile: path}})
+	require.NoError(t, err)
+	assert.Equal(t, 5, fromFile.Len())
+}
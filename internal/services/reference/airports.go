@@ -0,0 +1,158 @@
+package reference
+
+import (
+	"bytes"
+	_ "embed"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+)
+
+// airportsDataset is a selection of major airports in the OpenFlights
+// airports.dat format.
+//
+//go:embed airports.dat
+var airportsDataset []byte
+
+// Airports is an immutable airport catalog indexed by IATA code.
+type Airports struct {
+	airports []models.Airport
+	byIATA   map[string]int
+	// text holds the lower-cased name, city and country of every airport.
+	text []string
+	// cells is the spatial index of the airports.
+	cells map[cell][]int
+	// completions indexes the airports and metropolitan areas for
+	// autocompletion.
+	completions *completionIndex
+}
+
+// NewAirports loads the catalog from reference.airports_file, or from the
+// embedded dataset when no file is configured.
+func NewAirports(cfg config.Config) (*Airports, error) {
+	data, err := readDataset(cfg.Reference.AirportsFile, airportsDataset)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read airports file: %w", err)
+	}
+
+	airports, err := ParseAirports(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load airports: %w", err)
+	}
+
+	return airports, nil
+}
+
+// ParseAirports reads airports in the OpenFlights airports.dat format.
+// Airports without an IATA code are skipped, as routes cannot refer to them;
+// of airports sharing a code the first one is kept.
+func ParseAirports(r io.Reader) (*Airports, error) {
+	a := &Airports{byIATA: make(map[string]int)}
+
+	err := readRecords(r, 12, func(record []string) error {
+		airport, err := parseAirport(record)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := a.byIATA[airport.IATA]; ok || airport.IATA == "" {
+			return nil
+		}
+
+		a.byIATA[airport.IATA] = len(a.airports)
+		a.airports = append(a.airports, airport)
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	slices.SortFunc(a.airports, func(x, y models.Airport) int { return strings.Compare(x.IATA, y.IATA) })
+
+	a.text = make([]string, len(a.airports))
+	for i, airport := range a.airports {
+		a.byIATA[airport.IATA] = i
+		a.text[i] = strings.ToLower(airport.Name + "\x00" + airport.City + "\x00" + airport.Country)
+	}
+
+	a.index()
+	a.completions = newCompletionIndex(a.airports, a.byIATA)
+
+	return a, nil
+}
+
+func parseAirport(record []string) (models.Airport, error) {
+	field := func(i int) string { return openFlightsField(record, i) }
+
+	latitude, err := strconv.ParseFloat(field(6), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return models.Airport{}, fmt.Errorf("invalid latitude %q", record[6])
+	}
+
+	longitude, err := strconv.ParseFloat(field(7), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return models.Airport{}, fmt.Errorf("invalid longitude %q", record[7])
+	}
+
+	return models.Airport{
+		IATA:      field(4),
+		ICAO:      field(5),
+		Name:      field(1),
+		City:      field(2),
+		Country:   field(3),
+		Latitude:  latitude,
+		Longitude: longitude,
+		Timezone:  field(11),
+	}, nil
+}
+
+// Len returns the number of airports in the catalog.
+func (a *Airports) Len() int {
+	return len(a.airports)
+}
+
+// Get returns the airport with the given IATA code.
+func (a *Airports) Get(code string) (models.Airport, bool) {
+	i, ok := a.byIATA[code]
+	if !ok {
+		return models.Airport{}, false
+	}
+
+	return a.airports[i], true
+}
+
+// Search returns up to limit airports matching query, ignoring case. Airports
+// whose IATA or ICAO code equals the query come first, followed by those whose
+// name, city or country contains it, each ordered by IATA code.
+func (a *Airports) Search(query string, limit int) []models.Airport {
+	query = strings.TrimSpace(query)
+	lower := strings.ToLower(query)
+	upper := strings.ToUpper(query)
+
+	result := make([]models.Airport, 0, min(limit, 16))
+
+	if query == "" || limit <= 0 {
+		return result
+	}
+
+	var matches []models.Airport
+
+	for i, airport := range a.airports {
+		switch {
+		case airport.IATA == upper || airport.ICAO == upper:
+			result = append(result, airport)
+		case strings.Contains(a.text[i], lower):
+			matches = append(matches, airport)
+		}
+	}
+
+	result = append(result, matches...)
+
+	return result[:min(len(result), limit)]
+}
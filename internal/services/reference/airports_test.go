@@ -0,0 +1,144 @@
+package reference
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAirports = `1,"John F Kennedy International Airport","New York","United States","JFK","KJFK",40.639801,-73.7789,13,-5,"A","America/New_York","airport","OurAirports"
+2,"La Guardia Airport","New York","United States","LGA","KLGA",40.777199,-73.872597,21,-5,"A","America/New_York","airport","OurAirports"
+3,"Newark Liberty International Airport","Newark","United States","EWR",\N,40.692501,-74.168701,18,-5,"A",\N,"airport","OurAirports"
+4,"Private Strip","Nowhere","United States",\N,"XXXX",40,-74,18,-5,"A",\N,"airport","OurAirports"
+5,"Duplicate Kennedy","New York","United States","JFK","KJFK",0,0,13,-5,"A","America/New_York","airport","OurAirports"
+6,"Newcastle Airport","Newcastle","United Kingdom","NCL","EGNT",55.037498,-1.69167,266,0,"E","Europe/London","airport","OurAirports"
+`
+
+func codes(airports []models.Airport) []string {
+	result := make([]string, len(airports))
+	for i, airport := range airports {
+		result[i] = airport.IATA
+	}
+
+	return result
+}
+
+func TestParseAirports(t *testing.T) {
+	t.Parallel()
+
+	airports, err := ParseAirports(strings.NewReader(testAirports))
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, airports.Len(), "Airports without IATA code and duplicates should be skipped")
+
+	jfk, ok := airports.Get("JFK")
+	require.True(t, ok)
+	assert.Equal(t, models.Airport{
+		IATA: "JFK", ICAO: "KJFK", Name: "John F Kennedy International Airport", City: "New York",
+		Country: "United States", Latitude: 40.639801, Longitude: -73.7789, Timezone: "America/New_York",
+	}, jfk)
+
+	ewr, ok := airports.Get("EWR")
+	require.True(t, ok)
+	assert.Empty(t, ewr.ICAO, `\N should be read as a missing value`)
+	assert.Empty(t, ewr.Timezone)
+
+	_, ok = airports.Get("XXX")
+	assert.False(t, ok)
+}
+
+func TestParseAirports_Invalid(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		dataset string
+		wantErr string
+	}{
+		{
+			name:    "too few fields",
+			dataset: `1,"John F Kennedy International Airport","New York","United States","JFK"`,
+			wantErr: "line 1: expected at least 12 fields, got 5",
+		},
+		{
+			name: "latitude out of range",
+			dataset: `1,"John F Kennedy International Airport","New York","United States","JFK","KJFK",40.639801,-73.7789,13,-5,"A","America/New_York"
+2,"La Guardia Airport","New York","United States","LGA","KLGA",140.7,-73.87,21,-5,"A","America/New_York"`,
+			wantErr: `line 2: invalid latitude "140.7"`,
+		},
+		{
+			name:    "longitude not a number",
+			dataset: `1,"John F Kennedy International Airport","New York","United States","JFK","KJFK",40.639801,east,13,-5,"A","America/New_York"`,
+			wantErr: `line 1: invalid longitude "east"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := ParseAirports(strings.NewReader(tt.dataset))
+			assert.EqualError(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestAirports_Search(t *testing.T) {
+	t.Parallel()
+
+	airports, err := ParseAirports(strings.NewReader(testAirports))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query string
+		limit int
+		want  []string
+	}{
+		{name: "IATA code", query: "lga", limit: 10, want: []string{"LGA"}},
+		{name: "ICAO code", query: "EGNT", limit: 10, want: []string{"NCL"}},
+		{name: "city", query: "new york", limit: 10, want: []string{"JFK", "LGA"}},
+		{name: "substring matches ordered by code", query: "new", limit: 10, want: []string{"EWR", "JFK", "LGA", "NCL"}},
+		{name: "code before text", query: "ewr", limit: 10, want: []string{"EWR"}},
+		{name: "country", query: "Kingdom", limit: 10, want: []string{"NCL"}},
+		{name: "limit", query: "new", limit: 2, want: []string{"EWR", "JFK"}},
+		{name: "no match", query: "paris", limit: 10, want: []string{}},
+		{name: "blank query", query: "  ", limit: 10, want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, codes(airports.Search(tt.query, tt.limit)))
+		})
+	}
+}
+
+func TestNewAirports(t *testing.T) {
+	t.Parallel()
+
+	embedded, err := NewAirports(config.Config{})
+	require.NoError(t, err)
+	assert.Greater(t, embedded.Len(), 100)
+
+	lhr, ok := embedded.Get("LHR")
+	require.True(t, ok)
+	assert.Equal(t, "Europe/London", lhr.Timezone)
+
+	path := filepath.Join(t.TempDir(), "airports.dat")
+	require.NoError(t, os.WriteFile(path, []byte(testAirports), 0o600))
+
+	fromFile, err := NewAirports(config.Config{Reference: config.ReferenceConfig{AirportsFile: path}})
+	require.NoError(t, err)
+	assert.Equal(t, 4, fromFile.Len())
+
+	_, err = NewAirports(config.Config{Reference: config.ReferenceConfig{AirportsFile: filepath.Join(t.TempDir(), "missing")}})
+	assert.ErrorContains(t, err, "failed to read airports file")
+}
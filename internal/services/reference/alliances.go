@@ -0,0 +1,34 @@
+package reference
+
+// alliances maps every alliance to its member airlines by IATA code.
+var alliances = map[string][]string{
+	"oneworld": {
+		"AA", "AS", "AT", "AY", "BA", "CX", "FJ", "IB", "JL", "MH", "QF", "QR", "RJ", "UL", "WY",
+	},
+	"skyteam": {
+		"AF", "AM", "AR", "CI", "DL", "GA", "KE", "KL", "KQ", "ME", "MF", "MU", "RO", "SV", "UX", "VN", "VS",
+	},
+	"star_alliance": {
+		"A3", "AC", "AI", "AV", "BR", "CA", "CM", "ET", "LH", "LO", "LX", "MS", "NH", "NZ", "OS", "OU", "OZ",
+		"SA", "SK", "SN", "SQ", "TG", "TK", "TP", "UA", "ZH",
+	},
+}
+
+// allianceOf maps airlines to the alliance they are a member of.
+var allianceOf = func() map[string]string {
+	members := make(map[string]string)
+
+	for alliance, airlines := range alliances {
+		for _, airline := range airlines {
+			members[airline] = alliance
+		}
+	}
+
+	return members
+}()
+
+// AllianceOf returns the alliance the airline is a member of, or "" if it is
+// not a member of any.
+func AllianceOf(airline string) string {
+	return allianceOf[airline]
+}
@@ -0,0 +1,262 @@
+package reference
+
+import (
+	"cmp"
+	"maps"
+	"slices"
+	"strings"
+	"unicode"
+
+	"flight-booking/internal/models"
+)
+
+// MatchQuality tells how closely a completion matches its query, the closest
+// first.
+type MatchQuality int
+
+const (
+	// MatchCode is a query equal to the IATA or ICAO code of an airport, or
+	// to the code of a metropolitan area.
+	MatchCode MatchQuality = iota
+	// MatchPrefix is a query whose every word starts a word of the code, name
+	// or city.
+	MatchPrefix
+	// MatchFuzzy is a query with a word that only starts a word of the code,
+	// name or city once a typo is corrected.
+	MatchFuzzy
+)
+
+// Completion is an airport or metropolitan area matching an autocomplete
+// query.
+type Completion struct {
+	Suggestion models.Suggestion
+	Quality    MatchQuality
+}
+
+// completionIndex maps the codes and words of the airports and metropolitan
+// areas to them.
+type completionIndex struct {
+	suggestions []models.Suggestion
+	codes       map[string]int
+	// words holds every distinct lower-cased word, sorted, with the
+	// suggestions containing it.
+	words []indexedWord
+}
+
+type indexedWord struct {
+	text        string
+	suggestions []int
+}
+
+func newCompletionIndex(airports []models.Airport, byIATA map[string]int) *completionIndex {
+	x := &completionIndex{codes: make(map[string]int)}
+	words := make(map[string][]int)
+
+	add := func(suggestion models.Suggestion, codes []string, text string) {
+		i := len(x.suggestions)
+		x.suggestions = append(x.suggestions, suggestion)
+
+		keys := splitWords(text)
+		for _, code := range codes {
+			if code != "" {
+				x.codes[code] = i
+				keys = append(keys, strings.ToLower(code))
+			}
+		}
+
+		slices.Sort(keys)
+
+		for _, key := range slices.Compact(keys) {
+			words[key] = append(words[key], i)
+		}
+	}
+
+	for _, airport := range airports {
+		add(models.Suggestion{
+			Kind:    models.SuggestionAirport,
+			Code:    airport.IATA,
+			Name:    airport.Name,
+			City:    airport.City,
+			Country: airport.Country,
+		}, []string{airport.IATA, airport.ICAO}, airport.Name+" "+airport.City)
+	}
+
+	for _, code := range slices.Sorted(maps.Keys(metros)) {
+		area := metros[code]
+		suggestion := models.Suggestion{
+			Kind:     models.SuggestionMetro,
+			Code:     code,
+			Name:     area.city,
+			City:     area.city,
+			Airports: area.airports,
+		}
+
+		for _, member := range area.airports {
+			if i, ok := byIATA[member]; ok {
+				suggestion.Country = airports[i].Country
+
+				break
+			}
+		}
+
+		add(suggestion, []string{code}, area.city)
+	}
+
+	for text, suggestions := range words {
+		x.words = append(x.words, indexedWord{text: text, suggestions: suggestions})
+	}
+
+	slices.SortFunc(x.words, func(a, b indexedWord) int { return strings.Compare(a.text, b.text) })
+
+	return x
+}
+
+// Complete returns the airports and metropolitan areas matching query, the
+// closest matches first. Every word of the query has to start a word of the
+// code, name or city; words of four letters or more may have a typo, and of
+// eight or more two.
+func (a *Airports) Complete(query string) []Completion {
+	return a.completions.complete(query)
+}
+
+func (x *completionIndex) complete(query string) []Completion {
+	words := splitWords(query)
+	if len(words) == 0 {
+		return nil
+	}
+
+	var best map[int]MatchQuality
+
+	for n, word := range words {
+		matched := x.match(word)
+
+		if n == 0 {
+			best = matched
+
+			continue
+		}
+
+		for i, quality := range best {
+			if other, ok := matched[i]; ok {
+				best[i] = max(quality, other)
+			} else {
+				delete(best, i)
+			}
+		}
+	}
+
+	if i, ok := x.codes[strings.ToUpper(strings.TrimSpace(query))]; ok {
+		best[i] = MatchCode
+	}
+
+	completions := make([]Completion, 0, len(best))
+	for i, quality := range best {
+		completions = append(completions, Completion{Suggestion: x.suggestions[i], Quality: quality})
+	}
+
+	slices.SortFunc(completions, func(a, b Completion) int {
+		return cmp.Or(cmp.Compare(a.Quality, b.Quality), strings.Compare(a.Suggestion.Code, b.Suggestion.Code))
+	})
+
+	return completions
+}
+
+// match returns the suggestions with a word starting with word, or starting
+// with it once a typo is corrected.
+func (x *completionIndex) match(word string) map[int]MatchQuality {
+	matched := make(map[int]MatchQuality)
+
+	start, _ := slices.BinarySearchFunc(x.words, word, func(w indexedWord, text string) int {
+		return strings.Compare(w.text, text)
+	})
+
+	for _, w := range x.words[start:] {
+		if !strings.HasPrefix(w.text, word) {
+			break
+		}
+
+		for _, i := range w.suggestions {
+			matched[i] = MatchPrefix
+		}
+	}
+
+	typos := allowedTypos(word)
+	if typos == 0 {
+		return matched
+	}
+
+	query := []rune(word)
+
+	for _, w := range x.words {
+		if prefixDistance(query, []rune(w.text), typos) > typos {
+			continue
+		}
+
+		for _, i := range w.suggestions {
+			if _, ok := matched[i]; !ok {
+				matched[i] = MatchFuzzy
+			}
+		}
+	}
+
+	return matched
+}
+
+func allowedTypos(word string) int {
+	switch n := len([]rune(word)); {
+	case n >= 8:
+		return 2
+	case n >= 4:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// prefixDistance returns the smallest edit distance between query and a
+// prefix of text, or limit+1 once it exceeds limit.
+func prefixDistance(query, text []rune, limit int) int {
+	if len(text) < len(query)-limit {
+		return limit + 1
+	}
+
+	// row holds the distances between the query prefixes and text[:j].
+	row := make([]int, len(query)+1)
+	for i := range row {
+		row[i] = i
+	}
+
+	best := row[len(query)]
+
+	for j := range text[:min(len(text), len(query)+limit)] {
+		diagonal := row[0]
+		row[0] = j + 1
+		lowest := row[0]
+
+		for i := 1; i <= len(query); i++ {
+			substitution := diagonal
+			if query[i-1] != text[j] {
+				substitution++
+			}
+
+			diagonal = row[i]
+			row[i] = min(row[i]+1, row[i-1]+1, substitution)
+			lowest = min(lowest, row[i])
+		}
+
+		best = min(best, row[len(query)])
+
+		if lowest > limit {
+			break
+		}
+	}
+
+	return min(best, limit+1)
+}
+
+// splitWords returns the lower-cased words of text.
+func splitWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
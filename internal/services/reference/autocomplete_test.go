@@ -0,0 +1,108 @@
+package reference
+
+import (
+	"strings"
+	"testing"
+
+	"flight-booking/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAirports_Complete(t *testing.T) {
+	t.Parallel()
+
+	airports, err := ParseAirports(strings.NewReader(testAirports))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string
+		// quality is the match quality of every completion.
+		quality []MatchQuality
+	}{
+		{name: "IATA code", query: "JFK", want: []string{"JFK"}, quality: []MatchQuality{MatchCode}},
+		{name: "ICAO code", query: "egnt", want: []string{"NCL"}, quality: []MatchQuality{MatchCode}},
+		{name: "metro code", query: "nyc", want: []string{"NYC"}, quality: []MatchQuality{MatchCode}},
+		{
+			name:    "every word starts a word",
+			query:   "New Yo",
+			want:    []string{"JFK", "LGA", "NYC"},
+			quality: []MatchQuality{MatchPrefix, MatchPrefix, MatchPrefix},
+		},
+		{
+			name:    "prefixes before typos",
+			query:   "newc",
+			want:    []string{"NCL", "EWR", "JFK", "LGA", "NYC"},
+			quality: []MatchQuality{MatchPrefix, MatchFuzzy, MatchFuzzy, MatchFuzzy, MatchFuzzy},
+		},
+		{name: "typo", query: "nwark", want: []string{"EWR"}, quality: []MatchQuality{MatchFuzzy}},
+		{name: "metro city with a typo", query: "londn", want: []string{"LON"}, quality: []MatchQuality{MatchFuzzy}},
+		{name: "short words need to match exactly", query: "nwe", want: []string{}, quality: []MatchQuality{}},
+		{name: "blank query", query: " - ", want: []string{}, quality: []MatchQuality{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			completions := airports.Complete(tt.query)
+
+			got := make([]string, len(completions))
+			quality := make([]MatchQuality, len(completions))
+
+			for i, completion := range completions {
+				got[i] = completion.Suggestion.Code
+				quality[i] = completion.Quality
+			}
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.quality, quality)
+		})
+	}
+}
+
+func TestAirports_Complete_Metro(t *testing.T) {
+	t.Parallel()
+
+	airports, err := ParseAirports(strings.NewReader(testAirports))
+	require.NoError(t, err)
+
+	completions := airports.Complete("NYC")
+	require.Len(t, completions, 1)
+
+	nyc := completions[0].Suggestion
+	assert.Equal(t, "New York", nyc.Name)
+	assert.Equal(t, "United States", nyc.Country, "The country should be taken from the member airports")
+	assert.Equal(t, []string{"JFK", "EWR", "LGA"}, nyc.Airports)
+}
+
+func TestPrefixDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		query, text string
+		want        int
+	}{
+		{query: "lond", text: "london", want: 0},
+		{query: "lodnon", text: "london", want: 2},
+		{query: "londn", text: "london", want: 1},
+		{query: "frankfrt", text: "frankfurt", want: 1},
+		{query: "paris", text: "par", want: 2},
+		{query: "berlin", text: "london", want: 3},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, prefixDistance([]rune(tt.query), []rune(tt.text), 2), "%s in %s", tt.query, tt.text)
+	}
+}
+
+func BenchmarkAirports_Complete(b *testing.B) {
+	airports, err := NewAirports(config.Config{})
+	require.NoError(b, err)
+
+	for b.Loop() {
+		airports.Complete("san francsco")
+	}
+}
@@ -0,0 +1,38 @@
+package reference
+
+// metro is a metropolitan area served by several airports.
+type metro struct {
+	city     string
+	airports []string
+}
+
+// metros maps IATA metropolitan area codes to the areas. Codes that are also
+// used by one of the airports, such as BKK or SHA, are left out so that they
+// keep referring to the airport.
+var metros = map[string]metro{
+	"BJS": {city: "Beijing", airports: []string{"PEK", "PKX"}},
+	"BUE": {city: "Buenos Aires", airports: []string{"EZE", "AEP"}},
+	"CHI": {city: "Chicago", airports: []string{"ORD", "MDW"}},
+	"JKT": {city: "Jakarta", airports: []string{"CGK", "HLP"}},
+	"LON": {city: "London", airports: []string{"LHR", "LGW", "STN", "LTN", "LCY", "SEN"}},
+	"MIL": {city: "Milan", airports: []string{"MXP", "LIN", "BGY"}},
+	"MOW": {city: "Moscow", airports: []string{"SVO", "DME", "VKO"}},
+	"NYC": {city: "New York", airports: []string{"JFK", "EWR", "LGA"}},
+	"OSA": {city: "Osaka", airports: []string{"KIX", "ITM"}},
+	"PAR": {city: "Paris", airports: []string{"CDG", "ORY", "BVA"}},
+	"RIO": {city: "Rio de Janeiro", airports: []string{"GIG", "SDU"}},
+	"ROM": {city: "Rome", airports: []string{"FCO", "CIA"}},
+	"SAO": {city: "Sao Paulo", airports: []string{"GRU", "CGH", "VCP"}},
+	"SEL": {city: "Seoul", airports: []string{"ICN", "GMP"}},
+	"STO": {city: "Stockholm", airports: []string{"ARN", "BMA", "NYO"}},
+	"TYO": {city: "Tokyo", airports: []string{"HND", "NRT"}},
+	"WAS": {city: "Washington", airports: []string{"IAD", "DCA", "BWI"}},
+	"YMQ": {city: "Montreal", airports: []string{"YUL", "YMX"}},
+	"YTO": {city: "Toronto", airports: []string{"YYZ", "YTZ"}},
+}
+
+// MetroAirports returns the airports of the metropolitan area with the given
+// code, or nil if code is not a metropolitan area code.
+func MetroAirports(code string) []string {
+	return metros[code].airports
+}
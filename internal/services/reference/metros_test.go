@@ -0,0 +1,29 @@
+package reference
+
+import (
+	"testing"
+
+	"flight-booking/internal/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMetroAirports(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t, []string{"JFK", "EWR", "LGA"}, MetroAirports("NYC"))
+	assert.Nil(t, MetroAirports("JFK"))
+}
+
+func TestMetroCodesAreNotAirports(t *testing.T) {
+	t.Parallel()
+
+	airports, err := NewAirports(config.Config{})
+	require.NoError(t, err)
+
+	for code, metro := range metros {
+		_, ok := airports.Get(code)
+		assert.False(t, ok, "Metro code %s should not be an airport code", code)
+		assert.NotContains(t, metro.airports, code)
+	}
+}
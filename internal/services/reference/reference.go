@@ -0,0 +1,63 @@
+// Package reference provides catalogs of reference data, such as airports
+// and airlines, that routes refer to by code.
+package reference
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// openFlightsNull marks a missing value in OpenFlights datasets.
+const openFlightsNull = `\N`
+
+// readDataset returns the contents of the file at path, or the embedded
+// dataset when path is empty.
+func readDataset(path string, embedded []byte) ([]byte, error) {
+	if path == "" {
+		return embedded, nil
+	}
+
+	return os.ReadFile(path)
+}
+
+// readRecords calls parse with every record of an OpenFlights dataset,
+// reporting records with fewer than fields fields as errors.
+func readRecords(r io.Reader, fields int, parse func(record []string) error) error {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+
+		if err != nil {
+			return err
+		}
+
+		line, _ := reader.FieldPos(0)
+
+		if len(record) < fields {
+			return fmt.Errorf("line %d: expected at least %d fields, got %d", line, fields, len(record))
+		}
+
+		if err := parse(record); err != nil {
+			return fmt.Errorf("line %d: %w", line, err)
+		}
+	}
+}
+
+// openFlightsField returns the trimmed field i of record, or "" if it is null.
+func openFlightsField(record []string, i int) string {
+	if record[i] == openFlightsNull {
+		return ""
+	}
+
+	return strings.TrimSpace(record[i])
+}
@@ -0,0 +1,81 @@
+package reference
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+
+	"flight-booking/internal/models"
+)
+
+// cell is a one degree square of latitude and longitude in the spatial index.
+type cell struct {
+	lat, lon int
+}
+
+func cellOf(lat, lon float64) cell {
+	return cell{lat: int(math.Floor(lat)), lon: wrapLongitude(int(math.Floor(lon)))}
+}
+
+// wrapLongitude maps a cell longitude onto [-180, 180).
+func wrapLongitude(lon int) int {
+	return ((lon+180)%360+360)%360 - 180
+}
+
+// index groups the airports by the cell they are located in.
+func (a *Airports) index() {
+	a.cells = make(map[cell][]int)
+
+	for i, airport := range a.airports {
+		c := cellOf(airport.Latitude, airport.Longitude)
+		a.cells[c] = append(a.cells[c], i)
+	}
+}
+
+// Within returns the airports within radiusKm of the location, nearest first.
+// Only the cells overlapping the bounding box of the circle are searched.
+func (a *Airports) Within(lat, lon, radiusKm float64) []models.Airport {
+	center := models.Airport{Latitude: lat, Longitude: lon}
+	latSpan := radiusKm / models.KmPerDegree
+	minLat, maxLat := max(lat-latSpan, -90), min(lat+latSpan, 90)
+
+	// Degrees of longitude shrink towards the poles, so the box is widened by
+	// the latitude closest to a pole. Boxes reaching a pole, or nearly around
+	// the earth, cover every longitude.
+	minLon, maxLon := -180, 179
+
+	if widest := max(math.Abs(minLat), math.Abs(maxLat)); widest < 90 {
+		if lonSpan := latSpan / math.Cos(widest*math.Pi/180); lonSpan < 179 {
+			minLon, maxLon = int(math.Floor(lon-lonSpan)), int(math.Floor(lon+lonSpan))
+		}
+	}
+
+	type nearby struct {
+		airport models.Airport
+		km      float64
+	}
+
+	var found []nearby
+
+	for cellLat := int(math.Floor(minLat)); cellLat <= int(math.Floor(maxLat)); cellLat++ {
+		for cellLon := minLon; cellLon <= maxLon; cellLon++ {
+			for _, i := range a.cells[cell{lat: cellLat, lon: wrapLongitude(cellLon)}] {
+				if km := models.GreatCircleDistanceKm(center, a.airports[i]); km <= radiusKm {
+					found = append(found, nearby{airport: a.airports[i], km: km})
+				}
+			}
+		}
+	}
+
+	slices.SortFunc(found, func(x, y nearby) int {
+		return cmp.Or(cmp.Compare(x.km, y.km), strings.Compare(x.airport.IATA, y.airport.IATA))
+	})
+
+	airports := make([]models.Airport, len(found))
+	for i, f := range found {
+		airports[i] = f.airport
+	}
+
+	return airports
+}
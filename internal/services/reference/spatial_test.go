@@ -0,0 +1,57 @@
+package reference
+
+import (
+	"testing"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAirports_Within(t *testing.T) {
+	t.Parallel()
+
+	airports, err := NewAirports(config.Config{})
+	require.NoError(t, err)
+
+	london := airports.Within(51.5074, -0.1278, 60)
+	assert.Equal(t, []string{"LCY", "LHR", "LGW", "LTN", "STN"}, codes(london), "Airports should be ordered by distance")
+
+	assert.Empty(t, airports.Within(0, -140, 500), "The middle of the Pacific has no airports")
+}
+
+func TestAirports_Within_MatchesScan(t *testing.T) {
+	t.Parallel()
+
+	airports, err := NewAirports(config.Config{})
+	require.NoError(t, err)
+
+	centers := []struct {
+		name     string
+		lat, lon float64
+	}{
+		{"New York", 40.71, -74.01},
+		{"Singapore", 1.35, 103.82},
+		{"antimeridian", -17.75, 179.9},
+		{"antimeridian from the west", 21.3, -179.5},
+		{"North Pole", 89.9, 0},
+		{"South Pole", -89.9, 45},
+		{"Anchorage", 61.2, -149.9},
+	}
+
+	for _, center := range centers {
+		for _, radiusKm := range []float64{1, 100, 1500, 5000, 20000} {
+			var want []string
+
+			for _, airport := range airports.airports {
+				if models.GreatCircleDistanceKm(models.Airport{Latitude: center.lat, Longitude: center.lon}, airport) <= radiusKm {
+					want = append(want, airport.IATA)
+				}
+			}
+
+			assert.ElementsMatch(t, want, codes(airports.Within(center.lat, center.lon, radiusKm)),
+				"%s within %v km", center.name, radiusKm)
+		}
+	}
+}
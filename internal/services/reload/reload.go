@@ -0,0 +1,160 @@
+package reload
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/services/logger"
+	"github.com/fsnotify/fsnotify"
+	"go.uber.org/fx"
+)
+
+// debounce groups the burst of events editors produce when saving a file.
+const debounce = 500 * time.Millisecond
+
+// Reloader re-reads the configuration when its file changes or the process
+// receives SIGHUP. A new configuration is validated and only its reloadable
+// settings are applied; everything else keeps its startup value.
+type Reloader struct {
+	live   *config.Live
+	path   string
+	logger logger.Logger
+
+	mu      sync.Mutex
+	watcher *fsnotify.Watcher
+	signals chan os.Signal
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func New(cfg config.Config, live *config.Live, logger logger.Logger, lc fx.Lifecycle) *Reloader {
+	r := &Reloader{
+		live:   live,
+		path:   cfg.File,
+		logger: logger.With("component", "config_reloader"),
+	}
+
+	lc.Append(fx.Hook{
+		OnStart: r.start,
+		OnStop:  r.stop,
+	})
+
+	return r
+}
+
+func (r *Reloader) start(_ context.Context) error {
+	r.signals = make(chan os.Signal, 1)
+	r.done = make(chan struct{})
+	signal.Notify(r.signals, syscall.SIGHUP)
+
+	var events <-chan fsnotify.Event
+
+	if r.path != "" {
+		watcher, err := fsnotify.NewWatcher()
+		if err != nil {
+			return err
+		}
+
+		// The directory is watched rather than the file, so that files replaced
+		// by a rename, as editors and mounted config maps do, keep being followed.
+		if err := watcher.Add(filepath.Dir(r.path)); err != nil {
+			_ = watcher.Close()
+
+			return err
+		}
+
+		r.watcher = watcher
+		events = watcher.Events
+	}
+
+	r.wg.Add(1)
+
+	go r.loop(events)
+
+	return nil
+}
+
+func (r *Reloader) stop(_ context.Context) error {
+	signal.Stop(r.signals)
+	close(r.done)
+
+	if r.watcher != nil {
+		_ = r.watcher.Close()
+	}
+
+	r.wg.Wait()
+
+	return nil
+}
+
+func (r *Reloader) loop(events <-chan fsnotify.Event) {
+	defer r.wg.Done()
+
+	var pending <-chan time.Time
+
+	for {
+		select {
+		case <-r.done:
+			return
+		case <-r.signals:
+			r.logger.Info("received SIGHUP, reloading configuration")
+			r.Reload()
+		case event, ok := <-events:
+			if !ok {
+				events = nil
+
+				continue
+			}
+
+			if filepath.Clean(event.Name) == filepath.Clean(r.path) && event.Has(fsnotify.Write|fsnotify.Create) {
+				pending = time.After(debounce)
+			}
+		case <-pending:
+			pending = nil
+
+			r.logger.Info("config file changed, reloading configuration", "file", r.path)
+			r.Reload()
+		}
+	}
+}
+
+// Reload loads and validates the configuration and applies its reloadable
+// settings. An invalid configuration is rejected and the current one is kept.
+func (r *Reloader) Reload() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	next, err := config.New(r.path)
+	if err != nil {
+		r.logger.Error("configuration reload rejected", "error", err)
+
+		return
+	}
+
+	current := r.live.Get()
+	applied := config.Reloadable(current, next)
+
+	for _, change := range config.Diff(applied, next) {
+		r.logger.Warn("configuration change requires a restart and was not applied",
+			"key", change.Key, "current", change.Old, "requested", change.New)
+	}
+
+	changes := config.Diff(current, applied)
+	if len(changes) == 0 {
+		r.logger.Info("configuration reloaded without changes")
+
+		return
+	}
+
+	for _, change := range changes {
+		r.logger.Info("configuration changed", "key", change.Key, "old", change.Old, "new", change.New)
+	}
+
+	r.live.Set(applied)
+}
@@ -0,0 +1,60 @@
+package reload
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/services/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/fx/fxtest"
+)
+
+func configFile(port string, requestTimeout time.Duration, logLevel string) string {
+	return fmt.Sprintf(`
+server:
+  port: %q
+log:
+  level: %q
+aggregator:
+  request_timeout: %q
+providers:
+  provider1:
+    base_url: "http://localhost:8081"
+`, port, logLevel, requestTimeout)
+}
+
+func TestReloader_AppliesReloadableChangesOfTheFile(t *testing.T) {
+	t.Parallel()
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(configFile("8080", 10*time.Second, "info")), 0o600))
+
+	cfg, err := config.New(path)
+	require.NoError(t, err)
+
+	live := config.NewLive(cfg)
+	lc := fxtest.NewLifecycle(t)
+	New(cfg, live, logger.Context(t.Context()), lc)
+
+	lc.RequireStart()
+	defer lc.RequireStop()
+
+	require.NoError(t, os.WriteFile(path, []byte(configFile("9090", 3*time.Second, "info")), 0o600))
+
+	require.Eventually(t, func() bool {
+		return live.Get().Aggregator.RequestTimeout == 3*time.Second
+	}, 5*time.Second, 20*time.Millisecond, "A reloadable change should be applied")
+	assert.Equal(t, "8080", live.Get().Server.Port, "A restart-only change should not be applied")
+
+	require.NoError(t, os.WriteFile(path, []byte(configFile("8080", 5*time.Second, "verbose")), 0o600))
+
+	assert.Never(t, func() bool {
+		return live.Get().Aggregator.RequestTimeout != 3*time.Second
+	}, 2*debounce+500*time.Millisecond, 20*time.Millisecond, "An invalid file should keep the current config")
+	assert.Equal(t, "info", live.Get().Log.Level)
+}
@@ -0,0 +1,220 @@
+// Package routegraph searches itineraries over the graph formed by routes,
+// with airports as nodes and routes as edges.
+package routegraph
+
+import (
+	"cmp"
+	"slices"
+	"time"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+)
+
+// maxCandidates bounds the itineraries collected for one number of legs
+// before they are ranked, keeping searches between busy hubs fast.
+const maxCandidates = 10000
+
+// ConnectionRules sets the minimum time needed to change planes. Interline
+// connections, between legs of different airlines, usually need longer.
+type ConnectionRules struct {
+	Online    time.Duration
+	Interline time.Duration
+}
+
+// between returns the minimum connection time from arriving with one route to
+// departing with the next.
+func (r ConnectionRules) between(arriving, departing models.Route) time.Duration {
+	if arriving.Airline == departing.Airline {
+		return r.Online
+	}
+
+	return r.Interline
+}
+
+// Graph is an immutable route graph.
+type Graph struct {
+	routes []models.Route
+	from   map[string][]int32
+	// into maps every airport to the airports with a route into it.
+	into map[string][]string
+}
+
+// New builds the graph of routes. The slice is retained and must not be
+// modified afterwards.
+func New(routes []models.Route) *Graph {
+	g := &Graph{
+		routes: routes,
+		from:   make(map[string][]int32),
+		into:   make(map[string][]string),
+	}
+
+	linked := make(map[[2]string]bool)
+
+	for i, route := range routes {
+		position := int32(i) //nolint:gosec // route counts are far below math.MaxInt32
+		g.from[route.SourceAirport] = append(g.from[route.SourceAirport], position)
+
+		link := [2]string{route.SourceAirport, route.DestinationAirport}
+		if !linked[link] {
+			linked[link] = true
+			g.into[route.DestinationAirport] = append(g.into[route.DestinationAirport], route.SourceAirport)
+		}
+	}
+
+	return g
+}
+
+// Search returns up to query.Limit itineraries from the source to the
+// destination airport, fewest legs first. Itineraries with as many legs are
+// ordered by minimum connection time, then by the order of their routes. No
+// itinerary visits an airport twice.
+func (g *Graph) Search(query models.ItineraryQuery, rules ConnectionRules) []models.Itinerary {
+	limit := query.Limit
+	if limit <= 0 {
+		limit = models.DefaultItineraryLimit
+	}
+
+	maxLegs := query.MaxLegs
+	if maxLegs <= 0 {
+		maxLegs = models.DefaultMaxLegs
+	}
+
+	hops := g.hopsTo(query.DestinationAirport, maxLegs)
+
+	minLegs, ok := hops[query.SourceAirport]
+	if !ok || query.SourceAirport == query.DestinationAirport {
+		return []models.Itinerary{}
+	}
+
+	itineraries := make([]models.Itinerary, 0, limit)
+
+	for legs := minLegs; legs <= maxLegs && len(itineraries) < limit; legs++ {
+		s := &search{
+			graph:   g,
+			query:   query,
+			rules:   rules,
+			hops:    hops,
+			legs:    legs,
+			visited: map[string]bool{query.SourceAirport: true},
+		}
+
+		s.walk(query.SourceAirport)
+
+		slices.SortStableFunc(s.found, func(a, b models.Itinerary) int {
+			return cmp.Compare(a.MinConnectionTime, b.MinConnectionTime)
+		})
+
+		itineraries = append(itineraries, s.found[:min(len(s.found), limit-len(itineraries))]...)
+	}
+
+	return itineraries
+}
+
+// hopsTo returns the fewest legs needed to reach destination from every
+// airport that can reach it within maxLegs.
+func (g *Graph) hopsTo(destination string, maxLegs int) map[string]int {
+	hops := map[string]int{destination: 0}
+	frontier := []string{destination}
+
+	for depth := 1; depth <= maxLegs && len(frontier) > 0; depth++ {
+		var next []string
+
+		for _, airport := range frontier {
+			for _, source := range g.into[airport] {
+				if _, ok := hops[source]; !ok {
+					hops[source] = depth
+					next = append(next, source)
+				}
+			}
+		}
+
+		frontier = next
+	}
+
+	return hops
+}
+
+// search collects the itineraries with exactly legs legs.
+type search struct {
+	graph   *Graph
+	query   models.ItineraryQuery
+	rules   ConnectionRules
+	hops    map[string]int
+	legs    int
+	visited map[string]bool
+	path    []int32
+	found   []models.Itinerary
+}
+
+func (s *search) walk(airport string) {
+	// remaining is the number of legs still needed after the next one.
+	remaining := s.legs - len(s.path) - 1
+
+	for _, position := range s.graph.from[airport] {
+		if len(s.found) >= maxCandidates {
+			return
+		}
+
+		route := s.graph.routes[position]
+		next := route.DestinationAirport
+
+		hops, ok := s.hops[next]
+		if !ok || hops > remaining || s.visited[next] || !s.allowed(route) {
+			continue
+		}
+
+		if next == s.query.DestinationAirport && remaining > 0 {
+			continue
+		}
+
+		s.path = append(s.path, position)
+
+		if remaining == 0 {
+			s.found = append(s.found, s.itinerary())
+		} else {
+			s.visited[next] = true
+			s.walk(next)
+			delete(s.visited, next)
+		}
+
+		s.path = s.path[:len(s.path)-1]
+	}
+}
+
+// allowed reports whether route may follow the current path under the
+// carrier constraint of the query.
+func (s *search) allowed(route models.Route) bool {
+	if len(s.path) == 0 {
+		return true
+	}
+
+	first := s.graph.routes[s.path[0]].Airline
+
+	switch s.query.Carrier {
+	case models.CarrierAirline:
+		return route.Airline == first
+	case models.CarrierAlliance:
+		// Every leg so far is flown by the first airline or its alliance, so
+		// checking the new leg against the first one is enough.
+		alliance := reference.AllianceOf(first)
+
+		return route.Airline == first || (alliance != "" && reference.AllianceOf(route.Airline) == alliance)
+	default:
+		return true
+	}
+}
+
+func (s *search) itinerary() models.Itinerary {
+	itinerary := models.Itinerary{Legs: make([]models.Route, len(s.path))}
+
+	for i, position := range s.path {
+		itinerary.Legs[i] = s.graph.routes[position]
+
+		if i > 0 {
+			itinerary.MinConnectionTime += s.rules.between(itinerary.Legs[i-1], itinerary.Legs[i])
+		}
+	}
+
+	return itinerary
+}
@@ -0,0 +1,136 @@
+package routegraph
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+var rules = ConnectionRules{Online: 45 * time.Minute, Interline: 90 * time.Minute}
+
+func testGraph() *Graph {
+	return New([]models.Route{
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX"},
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "ORD"},
+		{Airline: "UA", SourceAirport: "ORD", DestinationAirport: "LAX"},
+		{Airline: "DL", SourceAirport: "JFK", DestinationAirport: "ATL"},
+		{Airline: "DL", SourceAirport: "ATL", DestinationAirport: "LAX"},
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "DFW"},
+		{Airline: "BA", SourceAirport: "DFW", DestinationAirport: "LAX"},
+		{Airline: "FR", SourceAirport: "DFW", DestinationAirport: "LAX"},
+		{Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK"},
+		{Airline: "UA", SourceAirport: "ORD", DestinationAirport: "ATL"},
+	})
+}
+
+// describe renders itineraries as "AA:JFK-LAX UA:LAX-ORD/90m".
+func describe(itineraries []models.Itinerary) []string {
+	result := make([]string, len(itineraries))
+
+	for i, itinerary := range itineraries {
+		legs := make([]string, len(itinerary.Legs))
+		for j, leg := range itinerary.Legs {
+			legs[j] = leg.Airline + ":" + leg.SourceAirport + "-" + leg.DestinationAirport
+		}
+
+		result[i] = strings.Join(legs, " ") + "/" + itinerary.MinConnectionTime.String()
+	}
+
+	return result
+}
+
+func TestGraph_Search(t *testing.T) {
+	t.Parallel()
+
+	graph := testGraph()
+
+	tests := []struct {
+		name  string
+		query models.ItineraryQuery
+		want  []string
+	}{
+		{
+			name:  "fewest legs first, then shortest connections",
+			query: models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "LAX"},
+			want: []string{
+				"AA:JFK-LAX/0s",
+				"UA:JFK-ORD UA:ORD-LAX/45m0s",
+				"DL:JFK-ATL DL:ATL-LAX/45m0s",
+				"AA:JFK-DFW BA:DFW-LAX/1h30m0s",
+				"AA:JFK-DFW FR:DFW-LAX/1h30m0s",
+			},
+		},
+		{
+			name:  "direct routes only",
+			query: models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "LAX", MaxLegs: 1},
+			want:  []string{"AA:JFK-LAX/0s"},
+		},
+		{
+			name:  "more legs without revisiting airports",
+			query: models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "LAX", MaxLegs: 4},
+			want: []string{
+				"AA:JFK-LAX/0s",
+				"UA:JFK-ORD UA:ORD-LAX/45m0s",
+				"DL:JFK-ATL DL:ATL-LAX/45m0s",
+				"AA:JFK-DFW BA:DFW-LAX/1h30m0s",
+				"AA:JFK-DFW FR:DFW-LAX/1h30m0s",
+				"UA:JFK-ORD UA:ORD-ATL DL:ATL-LAX/2h15m0s",
+			},
+		},
+		{
+			name:  "limit",
+			query: models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "LAX", Limit: 2},
+			want:  []string{"AA:JFK-LAX/0s", "UA:JFK-ORD UA:ORD-LAX/45m0s"},
+		},
+		{
+			name:  "same airline",
+			query: models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "LAX", MaxLegs: 3, Carrier: models.CarrierAirline},
+			want: []string{
+				"AA:JFK-LAX/0s",
+				"UA:JFK-ORD UA:ORD-LAX/45m0s",
+				"DL:JFK-ATL DL:ATL-LAX/45m0s",
+			},
+		},
+		{
+			name:  "same alliance",
+			query: models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "LAX", MaxLegs: 3, Carrier: models.CarrierAlliance},
+			want: []string{
+				"AA:JFK-LAX/0s",
+				"UA:JFK-ORD UA:ORD-LAX/45m0s",
+				"DL:JFK-ATL DL:ATL-LAX/45m0s",
+				"AA:JFK-DFW BA:DFW-LAX/1h30m0s",
+			},
+		},
+		{
+			name:  "connection only reachable through another airport",
+			query: models.ItineraryQuery{SourceAirport: "LAX", DestinationAirport: "ORD"},
+			want:  []string{"AA:LAX-JFK UA:JFK-ORD/1h30m0s"},
+		},
+		{
+			name:  "destination out of reach",
+			query: models.ItineraryQuery{SourceAirport: "LAX", DestinationAirport: "ATL", MaxLegs: 1},
+			want:  []string{},
+		},
+		{
+			name:  "unknown airport",
+			query: models.ItineraryQuery{SourceAirport: "XXX", DestinationAirport: "LAX"},
+			want:  []string{},
+		},
+		{
+			name:  "same source and destination",
+			query: models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "JFK"},
+			want:  []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			assert.Equal(t, tt.want, describe(graph.Search(tt.query, rules)))
+		})
+	}
+}
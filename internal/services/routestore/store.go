@@ -0,0 +1,226 @@
+// Package routestore provides an indexed, read-only set of routes that can be
+// filtered without scanning every route.
+package routestore
+
+import (
+	"slices"
+
+	"flight-booking/internal/models"
+)
+
+// Store is an immutable set of routes indexed by source airport, destination
+// airport and airline. Queries return routes in the order they were given.
+type Store struct {
+	routes        []models.Route
+	bySource      map[string][]int32
+	byDestination map[string][]int32
+	byAirline     map[string][]int32
+}
+
+// New indexes routes. The slice is retained and must not be modified afterwards.
+func New(routes []models.Route) *Store {
+	s := &Store{
+		routes:        routes,
+		bySource:      make(map[string][]int32),
+		byDestination: make(map[string][]int32),
+		byAirline:     make(map[string][]int32),
+	}
+
+	for i, route := range routes {
+		position := int32(i) //nolint:gosec // route counts are far below math.MaxInt32
+
+		s.bySource[route.SourceAirport] = append(s.bySource[route.SourceAirport], position)
+		s.byDestination[route.DestinationAirport] = append(s.byDestination[route.DestinationAirport], position)
+		s.byAirline[route.Airline] = append(s.byAirline[route.Airline], position)
+	}
+
+	return s
+}
+
+// Len returns the number of routes in the store.
+func (s *Store) Len() int {
+	return len(s.routes)
+}
+
+// HasAirline reports whether any route is operated by the airline.
+func (s *Store) HasAirline(airline string) bool {
+	return len(s.byAirline[airline]) > 0
+}
+
+// RouteCount returns the number of routes from or to the airport.
+func (s *Store) RouteCount(airport string) int {
+	return len(s.bySource[airport]) + len(s.byDestination[airport])
+}
+
+// Query returns the routes matching filters. Limit and Offset are ignored;
+// use Matches.Slice to page through the result.
+func (s *Store) Query(filters models.RouteFilters) Matches {
+	var postings [][]int32
+
+	for _, index := range []struct {
+		values []string
+		index  map[string][]int32
+		// unset tells whether the filter is not set, as opposed to matching
+		// no route.
+		unset bool
+	}{
+		{filters.SourceAirports, s.bySource, filters.SourceAirports == nil},
+		{filters.DestinationAirports, s.byDestination, filters.DestinationAirports == nil},
+		{filters.Airlines, s.byAirline, len(filters.Airlines) == 0},
+	} {
+		if index.unset {
+			continue
+		}
+
+		positions := lookup(index.index, index.values)
+		if len(positions) == 0 {
+			return Matches{store: s, positions: []int32{}}
+		}
+
+		postings = append(postings, positions)
+	}
+
+	match := unindexed(filters)
+
+	if len(postings) == 0 && match == nil {
+		return Matches{store: s, all: true}
+	}
+
+	var candidates []int32
+
+	if len(postings) > 0 {
+		slices.SortFunc(postings, func(a, b []int32) int { return len(a) - len(b) })
+
+		candidates = postings[0]
+		for _, positions := range postings[1:] {
+			candidates = intersect(candidates, positions)
+		}
+	}
+
+	if match != nil {
+		candidates = s.filter(candidates, len(postings) == 0, match)
+	}
+
+	return Matches{store: s, positions: candidates}
+}
+
+// unindexed returns a predicate for the filters not covered by an index, or
+// nil if none is set.
+func unindexed(filters models.RouteFilters) func(models.Route) bool {
+	if len(filters.ExcludeAirlines) == 0 && filters.CodeShare == "" && len(filters.Equipment) == 0 &&
+		filters.MaxStops == nil {
+		return nil
+	}
+
+	rest := filters
+	rest.Airlines, rest.SourceAirports, rest.DestinationAirports = nil, nil, nil
+
+	return rest.Match
+}
+
+// filter returns the candidates accepted by match, or every such route when
+// all is set. Candidates are never filtered in place, as they may be an index
+// posting list.
+func (s *Store) filter(candidates []int32, all bool, match func(models.Route) bool) []int32 {
+	if all {
+		matched := make([]int32, 0)
+
+		for i, route := range s.routes {
+			if match(route) {
+				matched = append(matched, int32(i)) //nolint:gosec // see New
+			}
+		}
+
+		return matched
+	}
+
+	matched := make([]int32, 0, len(candidates))
+
+	for _, position := range candidates {
+		if match(s.routes[position]) {
+			matched = append(matched, position)
+		}
+	}
+
+	return matched
+}
+
+// lookup returns the sorted positions of the routes indexed under any of
+// values.
+func lookup(index map[string][]int32, values []string) []int32 {
+	if len(values) == 1 {
+		return index[values[0]]
+	}
+
+	var positions []int32
+	for _, value := range values {
+		positions = append(positions, index[value]...)
+	}
+
+	slices.Sort(positions)
+
+	return slices.Compact(positions)
+}
+
+// intersect returns the positions present in both sorted lists.
+func intersect(a, b []int32) []int32 {
+	result := make([]int32, 0, min(len(a), len(b)))
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] < b[j]:
+			i++
+		case a[i] > b[j]:
+			j++
+		default:
+			result = append(result, a[i])
+			i++
+			j++
+		}
+	}
+
+	return result
+}
+
+// Matches is the ordered result of a query.
+type Matches struct {
+	store     *Store
+	positions []int32
+	all       bool
+}
+
+// Len returns the number of matching routes.
+func (m Matches) Len() int {
+	if m.all {
+		return m.store.Len()
+	}
+
+	return len(m.positions)
+}
+
+// Slice returns up to limit matching routes starting at offset. A negative
+// limit returns every route after offset.
+func (m Matches) Slice(offset, limit int) []models.Route {
+	total := m.Len()
+	offset = max(offset, 0)
+
+	if offset >= total {
+		return []models.Route{}
+	}
+
+	end := total
+	if limit >= 0 {
+		end = min(total, offset+limit)
+	}
+
+	if m.all {
+		return slices.Clone(m.store.routes[offset:end])
+	}
+
+	routes := make([]models.Route, 0, end-offset)
+	for _, position := range m.positions[offset:end] {
+		routes = append(routes, m.store.routes[position])
+	}
+
+	return routes
+}
@@ -0,0 +1,127 @@
+package routestore
+
+import (
+	"testing"
+
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func equipment(value string) *string {
+	return &value
+}
+
+func testRoutes() []models.Route {
+	return []models.Route{
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", CodeShare: "N", Stops: 0, Equipment: equipment("737 320")},
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "Y", Stops: 1},
+		{Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", CodeShare: "N", Stops: 0, Equipment: equipment("320")},
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "SFO", CodeShare: "Y", Stops: 2},
+		{Airline: "DL", SourceAirport: "ATL", DestinationAirport: "LAX", CodeShare: "N", Stops: 1, Equipment: equipment("757")},
+	}
+}
+
+func TestStore_Query(t *testing.T) {
+	t.Parallel()
+
+	store := New(testRoutes())
+	intPtr := func(i int) *int { return &i }
+
+	tests := []struct {
+		name    string
+		filters models.RouteFilters
+		want    []int
+	}{
+		{"no filters", models.RouteFilters{}, []int{0, 1, 2, 3, 4}},
+		{"source", models.RouteFilters{SourceAirports: []string{"JFK"}}, []int{0, 1, 3}},
+		{"destination", models.RouteFilters{DestinationAirports: []string{"LAX"}}, []int{0, 4}},
+		{"airline", models.RouteFilters{Airlines: []string{"AA"}}, []int{0, 2, 3}},
+		{"source and airline", models.RouteFilters{SourceAirports: []string{"JFK"}, Airlines: []string{"AA"}}, []int{0, 3}},
+		{
+			"source, destination and airline",
+			models.RouteFilters{SourceAirports: []string{"JFK"}, DestinationAirports: []string{"SFO"}, Airlines: []string{"UA"}},
+			[]int{1},
+		},
+		{"max stops only", models.RouteFilters{MaxStops: intPtr(0)}, []int{0, 2}},
+		{"source and max stops", models.RouteFilters{SourceAirports: []string{"JFK"}, MaxStops: intPtr(1)}, []int{0, 1}},
+		{"unknown value", models.RouteFilters{SourceAirports: []string{"XXX"}}, []int{}},
+		{"no sources", models.RouteFilters{SourceAirports: []string{}}, []int{}},
+		{"no destinations", models.RouteFilters{DestinationAirports: []string{}, Airlines: []string{"AA"}}, []int{}},
+		{"disjoint filters", models.RouteFilters{SourceAirports: []string{"ATL"}, Airlines: []string{"AA"}}, []int{}},
+		{"several airlines", models.RouteFilters{Airlines: []string{"UA", "DL"}}, []int{1, 4}},
+		{"several sources", models.RouteFilters{SourceAirports: []string{"LAX", "ATL", "XXX"}}, []int{2, 4}},
+		{
+			"several sources and destinations",
+			models.RouteFilters{SourceAirports: []string{"JFK", "ATL"}, DestinationAirports: []string{"LAX", "JFK"}},
+			[]int{0, 4},
+		},
+		{"repeated value", models.RouteFilters{Airlines: []string{"DL", "DL"}}, []int{4}},
+		{"excluded airline", models.RouteFilters{ExcludeAirlines: []string{"AA"}}, []int{1, 4}},
+		{
+			"source and excluded airlines",
+			models.RouteFilters{SourceAirports: []string{"JFK"}, ExcludeAirlines: []string{"UA", "DL"}},
+			[]int{0, 3},
+		},
+		{"airline both included and excluded", models.RouteFilters{Airlines: []string{"AA"}, ExcludeAirlines: []string{"AA"}}, []int{}},
+		{"code share", models.RouteFilters{CodeShare: "Y"}, []int{1, 3}},
+		{"equipment", models.RouteFilters{Equipment: []string{"320"}}, []int{0, 2}},
+		{"any of several equipment", models.RouteFilters{Equipment: []string{"757", "737"}}, []int{0, 4}},
+		{
+			"destination, code share and equipment",
+			models.RouteFilters{DestinationAirports: []string{"LAX"}, CodeShare: "N", Equipment: []string{"757"}},
+			[]int{4},
+		},
+		{"unknown equipment", models.RouteFilters{Equipment: []string{"380"}}, []int{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			want := make([]models.Route, len(tt.want))
+			for i, position := range tt.want {
+				want[i] = testRoutes()[position]
+			}
+
+			matches := store.Query(tt.filters)
+
+			assert.Equal(t, len(want), matches.Len())
+			assert.Equal(t, want, matches.Slice(0, -1))
+		})
+	}
+}
+
+func TestStore_RouteCount(t *testing.T) {
+	t.Parallel()
+
+	store := New(testRoutes())
+
+	assert.Equal(t, 4, store.RouteCount("JFK"))
+	assert.Equal(t, 3, store.RouteCount("LAX"))
+	assert.Zero(t, store.RouteCount("XXX"))
+}
+
+func TestStore_QueryDoesNotModifyIndex(t *testing.T) {
+	t.Parallel()
+
+	store := New(testRoutes())
+	maxStops := 0
+
+	store.Query(models.RouteFilters{SourceAirports: []string{"JFK"}, MaxStops: &maxStops})
+
+	assert.Equal(t, 3, store.Query(models.RouteFilters{SourceAirports: []string{"JFK"}}).Len())
+}
+
+func TestMatches_Slice(t *testing.T) {
+	t.Parallel()
+
+	store := New(testRoutes())
+	all := store.Query(models.RouteFilters{})
+	jfk := store.Query(models.RouteFilters{SourceAirports: []string{"JFK"}})
+
+	assert.Equal(t, testRoutes()[1:3], all.Slice(1, 2))
+	assert.Equal(t, testRoutes()[3:], all.Slice(3, 100))
+	assert.Empty(t, all.Slice(5, 10))
+	assert.Equal(t, []models.Route{testRoutes()[1], testRoutes()[3]}, jfk.Slice(1, -1))
+	assert.Empty(t, jfk.Slice(0, 0))
+}
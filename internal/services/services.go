@@ -1,18 +1,28 @@
 package services
 
 import (
+	"flight-booking/internal/config"
 	"flight-booking/internal/services/cache"
 	"flight-booking/internal/services/logger"
 	"flight-booking/internal/services/providers"
+	"flight-booking/internal/services/reference"
+	"flight-booking/internal/services/reload"
 	"go.uber.org/fx"
 )
 
 func Module() fx.Option {
 	return fx.Options(
 		fx.Provide(
+			config.NewLive,
 			cache.New,
 			logger.New,
 			providers.New,
+			providers.NewSnapshots,
+			providers.NewRegistry,
+			providers.NewWarmer,
+			reference.NewAirports,
+			reference.NewAirlines,
 		),
+		fx.Invoke(reload.New),
 	)
 }
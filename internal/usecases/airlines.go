@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+)
+
+type Airlines interface {
+	Get(code string) (models.Airline, error)
+	List(filters models.AirlineFilters) []models.Airline
+	Known(code string) bool
+}
+
+type airlines struct {
+	catalog   *reference.Airlines
+	snapshots RouteSnapshots
+}
+
+func NewAirlines(catalog *reference.Airlines, snapshots RouteSnapshots) Airlines {
+	return &airlines{
+		catalog:   catalog,
+		snapshots: snapshots,
+	}
+}
+
+// Get returns the airline with the given IATA code, or a not found error.
+func (a *airlines) Get(code string) (models.Airline, error) {
+	airline, ok := a.catalog.Get(code)
+	if !ok {
+		return models.Airline{}, apperrors.New(apperrors.NotFound, "no airline with code "+code)
+	}
+
+	return airline, nil
+}
+
+func (a *airlines) List(filters models.AirlineFilters) []models.Airline {
+	return a.catalog.List(filters)
+}
+
+// Known reports whether the airline is in the catalog or operates a route of
+// the latest provider data. Until provider data is loaded, airlines missing
+// from the catalog cannot be told apart from unknown ones and are accepted.
+func (a *airlines) Known(code string) bool {
+	if _, ok := a.catalog.Get(code); ok {
+		return true
+	}
+
+	store := a.snapshots.Current()
+
+	return store == nil || store.HasAirline(code)
+}
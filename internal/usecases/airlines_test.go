@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+	"flight-booking/internal/services/routestore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type staticSnapshots struct {
+	store *routestore.Store
+}
+
+func (s staticSnapshots) Current() *routestore.Store {
+	return s.store
+}
+
+func TestAirlines_Known(t *testing.T) {
+	t.Parallel()
+
+	catalog, err := reference.ParseAirlines(strings.NewReader(`1,"British Airways",\N,"BA","BAW","SPEEDBIRD","United Kingdom","Y"` + "\n"))
+	require.NoError(t, err)
+
+	loaded := NewAirlines(catalog, staticSnapshots{store: routestore.New([]models.Route{
+		{Airline: "UT", SourceAirport: "DME", DestinationAirport: "SGC"},
+	})})
+
+	assert.True(t, loaded.Known("BA"), "Airlines of the catalog are known")
+	assert.True(t, loaded.Known("UT"), "Airlines flying routes are known")
+	assert.False(t, loaded.Known("ZZ"))
+
+	assert.True(t, NewAirlines(catalog, staticSnapshots{}).Known("ZZ"), "Every airline is accepted before data is loaded")
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"flight-booking/internal/apperrors"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+)
+
+type Airports interface {
+	Get(code string) (models.Airport, error)
+	Search(query string, limit int) []models.Airport
+	Within(lat, lon, radiusKm float64) []models.Airport
+	Metro(code string) []string
+}
+
+type airports struct {
+	catalog *reference.Airports
+}
+
+func NewAirports(catalog *reference.Airports) Airports {
+	return &airports{
+		catalog: catalog,
+	}
+}
+
+// Get returns the airport with the given IATA code, or a not found error.
+func (a *airports) Get(code string) (models.Airport, error) {
+	airport, ok := a.catalog.Get(code)
+	if !ok {
+		return models.Airport{}, apperrors.New(apperrors.NotFound, "no airport with code "+code)
+	}
+
+	return airport, nil
+}
+
+func (a *airports) Search(query string, limit int) []models.Airport {
+	return a.catalog.Search(query, limit)
+}
+
+func (a *airports) Within(lat, lon, radiusKm float64) []models.Airport {
+	return a.catalog.Within(lat, lon, radiusKm)
+}
+
+// Metro returns the airports of the metropolitan area with the given code, or
+// nil if code is not a metropolitan area code.
+func (a *airports) Metro(code string) []string {
+	return reference.MetroAirports(code)
+}
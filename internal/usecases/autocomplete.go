@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"strings"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+)
+
+type Autocomplete interface {
+	Suggest(ctx context.Context, query string, limit int) ([]models.Suggestion, error)
+}
+
+type autocomplete struct {
+	catalog   *reference.Airports
+	snapshots RouteSnapshots
+}
+
+func NewAutocomplete(snapshots RouteSnapshots, catalog *reference.Airports) Autocomplete {
+	return &autocomplete{
+		catalog:   catalog,
+		snapshots: snapshots,
+	}
+}
+
+// Suggest returns up to limit airports and metropolitan areas matching query.
+// The closest matches come first, and among them the ones with the most
+// routes in the latest provider data. Route counts are read from the indexes
+// of the current route snapshot, so providers are never queried; before any
+// data is loaded every count is zero.
+func (a *autocomplete) Suggest(_ context.Context, query string, limit int) ([]models.Suggestion, error) {
+	completions := a.catalog.Complete(query)
+	if len(completions) == 0 || limit <= 0 {
+		return []models.Suggestion{}, nil
+	}
+
+	if store := a.snapshots.Current(); store != nil {
+		for i := range completions {
+			suggestion := &completions[i].Suggestion
+
+			suggestion.RouteCount = store.RouteCount(suggestion.Code)
+			for _, airport := range suggestion.Airports {
+				suggestion.RouteCount += store.RouteCount(airport)
+			}
+		}
+	}
+
+	slices.SortFunc(completions, func(x, y reference.Completion) int {
+		return cmp.Or(
+			cmp.Compare(x.Quality, y.Quality),
+			cmp.Compare(y.Suggestion.RouteCount, x.Suggestion.RouteCount),
+			strings.Compare(x.Suggestion.Code, y.Suggestion.Code),
+		)
+	})
+
+	suggestions := make([]models.Suggestion, min(limit, len(completions)))
+	for i := range suggestions {
+		suggestions[i] = completions[i].Suggestion
+	}
+
+	return suggestions, nil
+}
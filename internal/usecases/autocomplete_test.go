@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+	"flight-booking/internal/services/routestore"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAutocomplete_Suggest(t *testing.T) {
+	t.Parallel()
+
+	catalog, err := reference.ParseAirports(strings.NewReader(`` +
+		`1,"London Heathrow Airport","London","United Kingdom","LHR","EGLL",51.4706,-0.461941,83,0,"E","Europe/London","airport","OurAirports"` + "\n" +
+		`2,"London Gatwick Airport","London","United Kingdom","LGW","EGKK",51.148102,-0.190278,202,0,"E","Europe/London","airport","OurAirports"` + "\n" +
+		`3,"London City Airport","London","United Kingdom","LCY","EGLC",51.505299,0.055278,19,0,"E","Europe/London","airport","OurAirports"` + "\n",
+	))
+	require.NoError(t, err)
+
+	routes := []models.Route{
+		{Airline: "BA", SourceAirport: "LGW", DestinationAirport: "JFK"},
+		{Airline: "BA", SourceAirport: "LGW", DestinationAirport: "MAD"},
+		{Airline: "IB", SourceAirport: "MAD", DestinationAirport: "LGW"},
+		{Airline: "BA", SourceAirport: "LHR", DestinationAirport: "JFK"},
+	}
+
+	autocomplete := NewAutocomplete(staticSnapshots{store: routestore.New(routes)}, catalog)
+
+	suggestions, err := autocomplete.Suggest(t.Context(), "london", 10)
+	require.NoError(t, err)
+
+	got := make([]string, len(suggestions))
+	counts := make([]int, len(suggestions))
+
+	for i, suggestion := range suggestions {
+		got[i] = suggestion.Code
+		counts[i] = suggestion.RouteCount
+	}
+
+	assert.Equal(t, []string{"LON", "LGW", "LHR", "LCY"}, got, "Airports with more routes should come first")
+	assert.Equal(t, []int{4, 3, 1, 0}, counts)
+
+	suggestions, err = autocomplete.Suggest(t.Context(), "LHR", 10)
+	require.NoError(t, err)
+	require.NotEmpty(t, suggestions)
+	assert.Equal(t, "LHR", suggestions[0].Code, "Exact codes should come before busier airports")
+
+	suggestions, err = autocomplete.Suggest(t.Context(), "london", 2)
+	require.NoError(t, err)
+	assert.Len(t, suggestions, 2)
+
+	suggestions, err = NewAutocomplete(staticSnapshots{}, catalog).Suggest(t.Context(), "london", 10)
+	require.NoError(t, err)
+	require.Len(t, suggestions, 4, "Suggestions should not wait for provider data")
+	assert.Zero(t, suggestions[0].RouteCount)
+}
@@ -0,0 +1,76 @@
+package usecases
+
+import (
+	"sync"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+)
+
+// Distances estimates routes from the airport catalog. Distances are cached
+// per airport pair, as every pair is shared by the routes of many airlines.
+type Distances struct {
+	airports *reference.Airports
+
+	mu    sync.RWMutex
+	cache map[[2]string]distance
+}
+
+// distance is the cached distance of an airport pair; ok is false when an
+// airport is not in the catalog.
+type distance struct {
+	km float64
+	ok bool
+}
+
+func NewDistances(airports *reference.Airports) *Distances {
+	return &Distances{
+		airports: airports,
+		cache:    make(map[[2]string]distance),
+	}
+}
+
+// Annotate sets the estimate of every route whose airports are in the
+// catalog.
+func (d *Distances) Annotate(routes []models.Route) {
+	for i := range routes {
+		km, ok := d.Between(routes[i].SourceAirport, routes[i].DestinationAirport)
+		if !ok {
+			continue
+		}
+
+		routes[i].Estimate = &models.FlightEstimate{
+			DistanceKm: km,
+			BlockTime:  models.EstimateBlockTime(km, routes[i].Stops),
+		}
+	}
+}
+
+// Between returns the great-circle distance between two airports, or false if
+// either is not in the catalog.
+func (d *Distances) Between(source, destination string) (float64, bool) {
+	// Distances are symmetric, so both directions share an entry.
+	key := [2]string{min(source, destination), max(source, destination)}
+
+	d.mu.RLock()
+	cached, ok := d.cache[key]
+	d.mu.RUnlock()
+
+	if ok {
+		return cached.km, cached.ok
+	}
+
+	from, fromOK := d.airports.Get(source)
+	to, toOK := d.airports.Get(destination)
+
+	cached = distance{ok: fromOK && toOK}
+	if cached.ok {
+		cached.km = models.GreatCircleDistanceKm(from, to)
+	}
+
+	d.mu.Lock()
+	d.cache[key] = cached
+	d.mu.Unlock()
+
+	return cached.km, cached.ok
+}
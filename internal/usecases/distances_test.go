@@ -0,0 +1,122 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/reference"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestDistances returns distances over a catalog of JFK, LAX, LHR and SFO.
+func newTestDistances(t *testing.T) *Distances {
+	t.Helper()
+
+	airports, err := reference.ParseAirports(strings.NewReader(`` +
+		`1,"John F Kennedy International Airport","New York","United States","JFK","KJFK",40.639801,-73.7789,13,-5,"A","America/New_York","airport","OurAirports"` + "\n" +
+		`2,"Los Angeles International Airport","Los Angeles","United States","LAX","KLAX",33.942501,-118.407997,125,-8,"A","America/Los_Angeles","airport","OurAirports"` + "\n" +
+		`3,"London Heathrow Airport","London","United Kingdom","LHR","EGLL",51.4706,-0.461941,83,0,"E","Europe/London","airport","OurAirports"` + "\n" +
+		`4,"San Francisco International Airport","San Francisco","United States","SFO","KSFO",37.618999,-122.375,13,-8,"A","America/Los_Angeles","airport","OurAirports"` + "\n",
+	))
+	require.NoError(t, err)
+
+	return NewDistances(airports)
+}
+
+func TestDistances_Between(t *testing.T) {
+	t.Parallel()
+
+	distances := newTestDistances(t)
+
+	km, ok := distances.Between("JFK", "LHR")
+	require.True(t, ok)
+	assert.InDelta(t, 5540, km, 5)
+
+	back, ok := distances.Between("LHR", "JFK")
+	require.True(t, ok)
+	assert.InDelta(t, km, back, 1e-9, "Distances should be symmetric")
+
+	_, ok = distances.Between("JFK", "XXX")
+	assert.False(t, ok)
+
+	_, ok = distances.Between("JFK", "XXX")
+	assert.False(t, ok, "Unknown airports should stay unknown once cached")
+}
+
+func TestDistances_Annotate(t *testing.T) {
+	t.Parallel()
+
+	routes := []models.Route{
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX"},
+		{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", Stops: 1},
+		{Airline: "XX", SourceAirport: "JFK", DestinationAirport: "XXX"},
+	}
+
+	newTestDistances(t).Annotate(routes)
+
+	require.NotNil(t, routes[0].Estimate)
+	assert.InDelta(t, 3974, routes[0].Estimate.DistanceKm, 5)
+	assert.Equal(t, 5*time.Hour+28*time.Minute, routes[0].Estimate.BlockTime.Round(time.Minute))
+
+	require.NotNil(t, routes[1].Estimate)
+	assert.Equal(t, routes[0].Estimate.BlockTime+75*time.Minute, routes[1].Estimate.BlockTime,
+		"A stop should add ground time and another climb and descent")
+
+	assert.Nil(t, routes[2].Estimate)
+}
+
+func TestRoutes_GetRoutes_Distance(t *testing.T) {
+	t.Parallel()
+
+	km := func(km int) *int { return &km }
+
+	tests := []struct {
+		name    string
+		filters models.RouteFilters
+		want    []string
+		total   int
+	}{
+		{
+			name:    "minimum distance",
+			filters: models.RouteFilters{MinDistanceKm: km(4000)},
+			want:    []string{"BA/JFK/0", "UA/SFO/1"},
+			total:   2,
+		},
+		{
+			name:    "distance range",
+			filters: models.RouteFilters{MinDistanceKm: km(3900), MaxDistanceKm: km(4500)},
+			want:    []string{"AA/JFK/1", "AA/LAX/0", "UA/SFO/1"},
+			total:   3,
+		},
+		{
+			name:    "bounds apply to the rounded distance",
+			filters: models.RouteFilters{MinDistanceKm: km(5540)},
+			want:    []string{"BA/JFK/0"},
+			total:   1,
+		},
+		{
+			name:    "sort by distance",
+			filters: models.RouteFilters{Sort: []models.SortKey{{Field: models.SortDistanceKm, Descending: true}}},
+			want:    []string{"BA/JFK/0", "UA/SFO/1", "AA/JFK/1", "AA/LAX/0"},
+			total:   4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			filters := tt.filters
+			filters.Limit = 10
+
+			result, err := newSortTestRoutes(t).GetRoutes(t.Context(), filters)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.want, airlinesAndStops(result.Routes))
+			assert.Equal(t, tt.total, result.Total)
+		})
+	}
+}
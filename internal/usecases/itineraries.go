@@ -0,0 +1,53 @@
+package usecases
+
+import (
+	"context"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/providers"
+	"flight-booking/internal/services/routegraph"
+)
+
+type Itineraries interface {
+	Search(ctx context.Context, query models.ItineraryQuery) (models.ItinerariesResult, error)
+}
+
+type itineraries struct {
+	graphs    *snapshotView[*routegraph.Graph]
+	distances *Distances
+	config    *config.Live
+}
+
+func NewItineraries(provider providers.Provider, distances *Distances, config *config.Live) Itineraries {
+	return &itineraries{
+		graphs:    &snapshotView[*routegraph.Graph]{provider: provider, build: routegraph.New},
+		distances: distances,
+		config:    config,
+	}
+}
+
+// Search finds itineraries over every route of the current provider data. The
+// route graph is only rebuilt when the data changes.
+func (i *itineraries) Search(ctx context.Context, query models.ItineraryQuery) (models.ItinerariesResult, error) {
+	graph, reports, err := i.graphs.get(ctx)
+	if err != nil {
+		return models.ItinerariesResult{}, err
+	}
+
+	cfg := i.config.Get().Itineraries
+	rules := routegraph.ConnectionRules{
+		Online:    cfg.MinConnection,
+		Interline: cfg.MinInterlineConnection,
+	}
+
+	found := graph.Search(query, rules)
+	for _, itinerary := range found {
+		i.distances.Annotate(itinerary.Legs)
+	}
+
+	return models.ItinerariesResult{
+		Itineraries: found,
+		Providers:   reports,
+	}, nil
+}
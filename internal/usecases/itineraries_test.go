@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"flight-booking/internal/config"
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestItineraries_Search_RebuildsGraphOnNewSnapshot(t *testing.T) {
+	t.Parallel()
+
+	cfg, err := config.New("")
+	require.NoError(t, err)
+
+	snapshot := int64(1)
+	routes := []models.Route{
+		{Airline: "UA", SourceAirport: "JFK", DestinationAirport: "ORD"},
+		{Airline: "AA", SourceAirport: "ORD", DestinationAirport: "LAX"},
+	}
+	fullFetches := 0
+
+	provider := providerFunc(func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+		page := routes[:min(filters.Limit, len(routes))]
+		if filters.Limit == math.MaxInt {
+			fullFetches++
+		}
+
+		return models.RoutesResult{Routes: page, Total: len(routes), Snapshot: snapshot}, nil
+	})
+
+	itineraries := NewItineraries(provider, newTestDistances(t), config.NewLive(cfg))
+	query := models.ItineraryQuery{SourceAirport: "JFK", DestinationAirport: "LAX"}
+
+	result, err := itineraries.Search(t.Context(), query)
+	require.NoError(t, err)
+	require.Len(t, result.Itineraries, 1)
+	assert.Equal(t, 90*time.Minute, result.Itineraries[0].MinConnectionTime, "Interline connections use the interline time")
+
+	_, err = itineraries.Search(t.Context(), query)
+	require.NoError(t, err)
+	assert.Equal(t, 1, fullFetches, "The graph should be reused while the snapshot is unchanged")
+
+	snapshot = 2
+	routes = append(routes, models.Route{Airline: "DL", SourceAirport: "JFK", DestinationAirport: "LAX"})
+
+	result, err = itineraries.Search(t.Context(), query)
+	require.NoError(t, err)
+	assert.Equal(t, 2, fullFetches)
+	require.Len(t, result.Itineraries, 2)
+	assert.Equal(t, "DL", result.Itineraries[0].Legs[0].Airline)
+}
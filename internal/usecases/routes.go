@@ -1,32 +1,166 @@
 package usecases
 
 import (
+	"cmp"
 	"context"
 	"fmt"
+	"math"
+	"slices"
+	"strings"
 
 	"flight-booking/internal/models"
 	"flight-booking/internal/services/providers"
 )
 
 type Routes interface {
-	GetRoutes(ctx context.Context, filters models.RouteFilters) ([]models.Route, error)
+	GetRoutes(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error)
 }
 
 type routes struct {
-	provider providers.Provider
+	provider  providers.Provider
+	distances *Distances
 }
 
-func NewRoutes(provider providers.Provider) Routes {
+func NewRoutes(provider providers.Provider, distances *Distances) Routes {
 	return &routes{
-		provider: provider,
+		provider:  provider,
+		distances: distances,
 	}
 }
 
-func (r *routes) GetRoutes(ctx context.Context, filters models.RouteFilters) ([]models.Route, error) {
-	routes, err := r.provider.GetRoutes(ctx, filters)
+// GetRoutes returns a page of the routes matching filters, with their
+// estimates. When a sort order or a distance filter is requested, every
+// matching route is fetched, filtered and sorted before the page is cut, so
+// that pages follow the requested order.
+func (r *routes) GetRoutes(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+	filtersDistance := filters.MinDistanceKm != nil || filters.MaxDistanceKm != nil
+
+	if len(filters.Sort) == 0 && !filtersDistance {
+		result, err := r.provider.GetRoutes(ctx, filters)
+		if err != nil {
+			return models.RoutesResult{}, fmt.Errorf("failed to get routes from provider: %w", err)
+		}
+
+		r.distances.Annotate(result.Routes)
+
+		return result, nil
+	}
+
+	all := filters
+	all.Limit = math.MaxInt
+	all.Offset = 0
+
+	offset := filters.Offset
+	if filters.Cursor != nil {
+		cursor := *filters.Cursor
+		offset = cursor.Position
+		cursor.Position = 0
+		all.Cursor = &cursor
+	}
+
+	result, err := r.provider.GetRoutes(ctx, all)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get routes from provider: %w", err)
+		return models.RoutesResult{}, fmt.Errorf("failed to get routes from provider: %w", err)
+	}
+
+	r.distances.Annotate(result.Routes)
+
+	if filtersDistance {
+		result.Routes = slices.DeleteFunc(result.Routes, func(route models.Route) bool {
+			return !withinDistance(route, filters)
+		})
+		result.Total = len(result.Routes)
+	}
+
+	sortRoutes(result.Routes, filters.Sort)
+
+	limit := filters.Limit
+	if limit <= 0 {
+		limit = models.DefaultRouteLimit
+	}
+
+	start := min(offset, len(result.Routes))
+	end := start + min(limit, len(result.Routes)-start)
+
+	result.Routes = result.Routes[start:end]
+	result.Offset = offset
+
+	return result, nil
+}
+
+// withinDistance reports whether the distance of route, rounded as reported
+// to clients, is within the bounds of filters.
+func withinDistance(route models.Route, filters models.RouteFilters) bool {
+	if route.Estimate == nil {
+		return false
+	}
+
+	km := int(math.Round(route.Estimate.DistanceKm))
+
+	return (filters.MinDistanceKm == nil || km >= *filters.MinDistanceKm) &&
+		(filters.MaxDistanceKm == nil || km <= *filters.MaxDistanceKm)
+}
+
+// sortRoutes orders routes by the given keys. The sort is stable, so routes
+// equal on every key keep their order.
+func sortRoutes(routes []models.Route, keys []models.SortKey) {
+	slices.SortStableFunc(routes, func(a, b models.Route) int {
+		for _, key := range keys {
+			c := compareField(a, b, key.Field)
+			if key.Descending {
+				c = -c
+			}
+
+			if c != 0 {
+				return c
+			}
+		}
+
+		return 0
+	})
+}
+
+func compareField(a, b models.Route, field string) int {
+	switch field {
+	case models.SortAirline:
+		return strings.Compare(a.Airline, b.Airline)
+	case models.SortSourceAirport:
+		return strings.Compare(a.SourceAirport, b.SourceAirport)
+	case models.SortDestinationAirport:
+		return strings.Compare(a.DestinationAirport, b.DestinationAirport)
+	case models.SortCodeShare:
+		return strings.Compare(a.CodeShare, b.CodeShare)
+	case models.SortStops:
+		return cmp.Compare(a.Stops, b.Stops)
+	case models.SortEquipment:
+		return compareOptional(a.Equipment, b.Equipment)
+	case models.SortDistanceKm:
+		return compareOptional(distanceOf(a), distanceOf(b))
+	case models.SortProvider:
+		return strings.Compare(a.Provider, b.Provider)
+	}
+
+	return 0
+}
+
+func distanceOf(route models.Route) *float64 {
+	if route.Estimate == nil {
+		return nil
+	}
+
+	return &route.Estimate.DistanceKm
+}
+
+// compareOptional orders missing values before present ones.
+func compareOptional[T cmp.Ordered](a, b *T) int {
+	switch {
+	case a == nil && b == nil:
+		return 0
+	case a == nil:
+		return -1
+	case b == nil:
+		return 1
 	}
 
-	return routes, nil
+	return cmp.Compare(*a, *b)
 }
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"flight-booking/internal/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type providerFunc func(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error)
+
+func (f providerFunc) GetRoutes(ctx context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+	return f(ctx, filters)
+}
+
+func equipment(value string) *string {
+	return &value
+}
+
+var sortTestRoutes = []models.Route{
+	{Airline: "AA", SourceAirport: "JFK", DestinationAirport: "LAX", Stops: 1, Equipment: equipment("737")},
+	{Airline: "BA", SourceAirport: "JFK", DestinationAirport: "LHR", Stops: 0},
+	{Airline: "AA", SourceAirport: "LAX", DestinationAirport: "JFK", Stops: 0, Equipment: equipment("320")},
+	{Airline: "UA", SourceAirport: "SFO", DestinationAirport: "JFK", Stops: 1},
+}
+
+func newSortTestRoutes(t *testing.T) Routes {
+	t.Helper()
+
+	return NewRoutes(providerFunc(func(_ context.Context, filters models.RouteFilters) (models.RoutesResult, error) {
+		if filters.Sort != nil {
+			assert.Equal(t, 0, filters.Offset, "Sorted queries should fetch every route")
+
+			if filters.Cursor != nil {
+				assert.Equal(t, 0, filters.Cursor.Position)
+			}
+		}
+
+		routes := append([]models.Route{}, sortTestRoutes...)
+
+		return models.RoutesResult{Routes: routes, Total: len(routes), Snapshot: 7}, nil
+	}), newTestDistances(t))
+}
+
+func airlinesAndStops(routes []models.Route) []string {
+	result := make([]string, len(routes))
+	for i, route := range routes {
+		result[i] = route.Airline + "/" + route.SourceAirport + "/" + string(rune('0'+route.Stops))
+	}
+
+	return result
+}
+
+func TestRoutes_GetRoutes_Sort(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		sort string
+		want []string
+	}{
+		{"single key", "stops", []string{"BA/JFK/0", "AA/LAX/0", "AA/JFK/1", "UA/SFO/1"}},
+		{"descending key", "-airline", []string{"UA/SFO/1", "BA/JFK/0", "AA/JFK/1", "AA/LAX/0"}},
+		{"multiple keys", "stops,-airline", []string{"BA/JFK/0", "AA/LAX/0", "UA/SFO/1", "AA/JFK/1"}},
+		{"missing values first", "equipment", []string{"BA/JFK/0", "UA/SFO/1", "AA/LAX/0", "AA/JFK/1"}},
+		{"missing values last when descending", "-equipment", []string{"AA/JFK/1", "AA/LAX/0", "BA/JFK/0", "UA/SFO/1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			sort, err := models.ParseSort(tt.sort)
+			require.NoError(t, err)
+
+			result, err := newSortTestRoutes(t).GetRoutes(t.Context(), models.RouteFilters{Sort: sort})
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, airlinesAndStops(result.Routes))
+		})
+	}
+}
+
+func TestRoutes_GetRoutes_SortedPages(t *testing.T) {
+	t.Parallel()
+
+	sort, err := models.ParseSort("-stops,airline")
+	require.NoError(t, err)
+
+	routes := newSortTestRoutes(t)
+
+	page, err := routes.GetRoutes(t.Context(), models.RouteFilters{Sort: sort, Limit: 3, Offset: 1})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"UA/SFO/1", "AA/LAX/0", "BA/JFK/0"}, airlinesAndStops(page.Routes))
+	assert.Equal(t, 1, page.Offset)
+	assert.Equal(t, 4, page.Total)
+
+	cursor := &models.Cursor{Snapshot: 7, Position: 3}
+
+	page, err = routes.GetRoutes(t.Context(), models.RouteFilters{Sort: sort, Limit: 3, Cursor: cursor})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BA/JFK/0"}, airlinesAndStops(page.Routes))
+	assert.Equal(t, 3, page.Offset)
+	assert.Equal(t, 3, cursor.Position, "The caller's cursor should not be modified")
+
+	page, err = routes.GetRoutes(t.Context(), models.RouteFilters{Sort: sort, Offset: 10})
+	require.NoError(t, err)
+	assert.Empty(t, page.Routes)
+}
@@ -0,0 +1,57 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+
+	"flight-booking/internal/models"
+	"flight-booking/internal/services/providers"
+)
+
+// snapshotView keeps a value built from every route of the latest provider
+// snapshot. A single route is requested first to learn the snapshot, so that
+// every route is only fetched when the value has to be rebuilt.
+type snapshotView[T any] struct {
+	provider providers.Provider
+	build    func(routes []models.Route) T
+
+	mu       sync.Mutex
+	value    T
+	built    bool
+	snapshot int64
+}
+
+func (v *snapshotView[T]) get(ctx context.Context) (T, models.ProviderReports, error) {
+	var zero T
+
+	probe, err := v.provider.GetRoutes(ctx, models.RouteFilters{Limit: 1})
+	if err != nil {
+		return zero, nil, fmt.Errorf("failed to get routes from provider: %w", err)
+	}
+
+	v.mu.Lock()
+	value := v.value
+	current := v.built && probe.Snapshot != 0 && probe.Snapshot == v.snapshot
+	v.mu.Unlock()
+
+	if current {
+		return value, probe.Providers, nil
+	}
+
+	result, err := v.provider.GetRoutes(ctx, models.RouteFilters{Limit: math.MaxInt})
+	if err != nil {
+		return zero, nil, fmt.Errorf("failed to get routes from provider: %w", err)
+	}
+
+	value = v.build(result.Routes)
+
+	v.mu.Lock()
+	v.value = value
+	v.built = true
+	v.snapshot = result.Snapshot
+	v.mu.Unlock()
+
+	return value, result.Providers, nil
+}
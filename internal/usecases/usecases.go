@@ -1,11 +1,27 @@
 package usecases
 
-import "go.uber.org/fx"
+import (
+	"flight-booking/internal/services/providers"
+	"flight-booking/internal/services/routestore"
+	"go.uber.org/fx"
+)
+
+// RouteSnapshots gives the route store of the latest provider data without
+// querying providers, or nil if no data has been loaded yet.
+type RouteSnapshots interface {
+	Current() *routestore.Store
+}
 
 func Module() fx.Option {
 	return fx.Options(
 		fx.Provide(
 			NewRoutes,
+			NewItineraries,
+			NewAirports,
+			NewAirlines,
+			NewDistances,
+			NewAutocomplete,
+			func(snapshots *providers.Snapshots) RouteSnapshots { return snapshots },
 		),
 	)
 }
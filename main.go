@@ -1,6 +1,10 @@
 package main
 
 import (
+	_ "embed"
+	"flag"
+	"fmt"
+	"os"
 	"time"
 
 	"flight-booking/internal/api"
@@ -10,19 +14,26 @@ import (
 	"go.uber.org/fx"
 )
 
-const (
-	MaxShutdownTime = 20 * time.Second
-)
+// shutdownMargin is added to the server's graceful shutdown timeout so the
+// remaining stop hooks still run after in-flight requests have drained.
+const shutdownMargin = 5 * time.Second
+
+//go:embed openapi.yaml
+var openAPISpec []byte
 
 func main() {
-	conf, err := config.New()
+	configPath := flag.String("config", "", "path to the YAML config file (defaults to $CONFIG_FILE)")
+	flag.Parse()
+
+	conf, err := config.New(*configPath)
 	if err != nil {
-		panic(err)
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
 	}
 
 	app := fx.New(
-		fx.Supply(conf),
-		fx.StopTimeout(MaxShutdownTime),
+		fx.Supply(conf, api.Spec(openAPISpec)),
+		fx.StopTimeout(conf.Server.GracefulShutdownTimeout+shutdownMargin),
 		api.Module(),
 		services.Module(),
 		usecases.Module(),